@@ -121,6 +121,115 @@ func (FanUnit) EnumDescriptor() ([]byte, []int) {
 	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{1}
 }
 
+// FanFailure defines the fan failure detected by the fan health monitor
+type FanFailure int32
+
+const (
+	FanFailure_FAN_FAILURE_NONE             FanFailure = 0
+	FanFailure_FAN_FAILURE_STALL            FanFailure = 1
+	FanFailure_FAN_FAILURE_TACH_MISSING     FanFailure = 2
+	FanFailure_FAN_FAILURE_RPM_OUT_OF_RANGE FanFailure = 3
+)
+
+// Enum value maps for FanFailure.
+var (
+	FanFailure_name = map[int32]string{
+		0: "FAN_FAILURE_NONE",
+		1: "FAN_FAILURE_STALL",
+		2: "FAN_FAILURE_TACH_MISSING",
+		3: "FAN_FAILURE_RPM_OUT_OF_RANGE",
+	}
+	FanFailure_value = map[string]int32{
+		"FAN_FAILURE_NONE":             0,
+		"FAN_FAILURE_STALL":            1,
+		"FAN_FAILURE_TACH_MISSING":     2,
+		"FAN_FAILURE_RPM_OUT_OF_RANGE": 3,
+	}
+)
+
+func (x FanFailure) Enum() *FanFailure {
+	p := new(FanFailure)
+	*p = x
+	return p
+}
+
+func (x FanFailure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FanFailure) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[2].Descriptor()
+}
+
+func (FanFailure) Type() protoreflect.EnumType {
+	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[2]
+}
+
+func (x FanFailure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FanFailure.Descriptor instead.
+func (FanFailure) EnumDescriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{2}
+}
+
+// Fault defines a fault condition of the agent, the value is the blink code shown on the top LED
+type Fault int32
+
+const (
+	Fault_FAULT_UNSPECIFIED            Fault = 0
+	Fault_FAULT_TEMPERATURE_SENSOR     Fault = 1
+	Fault_FAULT_FAN_CONTROL            Fault = 2
+	Fault_FAULT_SMART_FAN_UNIT_MISSING Fault = 3
+	Fault_FAULT_CERTIFICATE            Fault = 4
+)
+
+// Enum value maps for Fault.
+var (
+	Fault_name = map[int32]string{
+		0: "FAULT_UNSPECIFIED",
+		1: "FAULT_TEMPERATURE_SENSOR",
+		2: "FAULT_FAN_CONTROL",
+		3: "FAULT_SMART_FAN_UNIT_MISSING",
+		4: "FAULT_CERTIFICATE",
+	}
+	Fault_value = map[string]int32{
+		"FAULT_UNSPECIFIED":            0,
+		"FAULT_TEMPERATURE_SENSOR":     1,
+		"FAULT_FAN_CONTROL":            2,
+		"FAULT_SMART_FAN_UNIT_MISSING": 3,
+		"FAULT_CERTIFICATE":            4,
+	}
+)
+
+func (x Fault) Enum() *Fault {
+	p := new(Fault)
+	*p = x
+	return p
+}
+
+func (x Fault) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Fault) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[3].Descriptor()
+}
+
+func (Fault) Type() protoreflect.EnumType {
+	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[3]
+}
+
+func (x Fault) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Fault.Descriptor instead.
+func (Fault) EnumDescriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{3}
+}
+
 // PowerStatus defines the power status of the blade
 type PowerStatus int32
 
@@ -152,11 +261,11 @@ func (x PowerStatus) String() string {
 }
 
 func (PowerStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[2].Descriptor()
+	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[4].Descriptor()
 }
 
 func (PowerStatus) Type() protoreflect.EnumType {
-	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[2]
+	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[4]
 }
 
 func (x PowerStatus) Number() protoreflect.EnumNumber {
@@ -165,19 +274,175 @@ func (x PowerStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PowerStatus.Descriptor instead.
 func (PowerStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{2}
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{4}
 }
 
-type StealthModeRequest struct {
+// LedIndex defines the LED of the blade
+type LedIndex int32
+
+const (
+	LedIndex_LED_TOP  LedIndex = 0
+	LedIndex_LED_EDGE LedIndex = 1
+)
+
+// Enum value maps for LedIndex.
+var (
+	LedIndex_name = map[int32]string{
+		0: "LED_TOP",
+		1: "LED_EDGE",
+	}
+	LedIndex_value = map[string]int32{
+		"LED_TOP":  0,
+		"LED_EDGE": 1,
+	}
+)
+
+func (x LedIndex) Enum() *LedIndex {
+	p := new(LedIndex)
+	*p = x
+	return p
+}
+
+func (x LedIndex) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LedIndex) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[5].Descriptor()
+}
+
+func (LedIndex) Type() protoreflect.EnumType {
+	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[5]
+}
+
+func (x LedIndex) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LedIndex.Descriptor instead.
+func (LedIndex) EnumDescriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{5}
+}
+
+// LedPattern defines the animation shown on an LED
+type LedPattern int32
+
+const (
+	LedPattern_LED_PATTERN_STATIC     LedPattern = 0
+	LedPattern_LED_PATTERN_SLOW_BLINK LedPattern = 1
+	LedPattern_LED_PATTERN_BURST      LedPattern = 2
+	LedPattern_LED_PATTERN_BREATHING  LedPattern = 3
+	// LED_PATTERN_KEYFRAMES shows the keyframes of the request
+	LedPattern_LED_PATTERN_KEYFRAMES LedPattern = 4
+)
+
+// Enum value maps for LedPattern.
+var (
+	LedPattern_name = map[int32]string{
+		0: "LED_PATTERN_STATIC",
+		1: "LED_PATTERN_SLOW_BLINK",
+		2: "LED_PATTERN_BURST",
+		3: "LED_PATTERN_BREATHING",
+		4: "LED_PATTERN_KEYFRAMES",
+	}
+	LedPattern_value = map[string]int32{
+		"LED_PATTERN_STATIC":     0,
+		"LED_PATTERN_SLOW_BLINK": 1,
+		"LED_PATTERN_BURST":      2,
+		"LED_PATTERN_BREATHING":  3,
+		"LED_PATTERN_KEYFRAMES":  4,
+	}
+)
+
+func (x LedPattern) Enum() *LedPattern {
+	p := new(LedPattern)
+	*p = x
+	return p
+}
+
+func (x LedPattern) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LedPattern) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[6].Descriptor()
+}
+
+func (LedPattern) Type() protoreflect.EnumType {
+	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[6]
+}
+
+func (x LedPattern) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LedPattern.Descriptor instead.
+func (LedPattern) EnumDescriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{6}
+}
+
+// LedInterpolation defines how the color changes towards the color of a keyframe
+type LedInterpolation int32
+
+const (
+	LedInterpolation_LED_INTERPOLATION_STEP   LedInterpolation = 0
+	LedInterpolation_LED_INTERPOLATION_LINEAR LedInterpolation = 1
+	LedInterpolation_LED_INTERPOLATION_EASE   LedInterpolation = 2
+)
+
+// Enum value maps for LedInterpolation.
+var (
+	LedInterpolation_name = map[int32]string{
+		0: "LED_INTERPOLATION_STEP",
+		1: "LED_INTERPOLATION_LINEAR",
+		2: "LED_INTERPOLATION_EASE",
+	}
+	LedInterpolation_value = map[string]int32{
+		"LED_INTERPOLATION_STEP":   0,
+		"LED_INTERPOLATION_LINEAR": 1,
+		"LED_INTERPOLATION_EASE":   2,
+	}
+)
+
+func (x LedInterpolation) Enum() *LedInterpolation {
+	p := new(LedInterpolation)
+	*p = x
+	return p
+}
+
+func (x LedInterpolation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LedInterpolation) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_bladeapi_v1alpha1_blade_proto_enumTypes[7].Descriptor()
+}
+
+func (LedInterpolation) Type() protoreflect.EnumType {
+	return &file_api_bladeapi_v1alpha1_blade_proto_enumTypes[7]
+}
+
+func (x LedInterpolation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LedInterpolation.Descriptor instead.
+func (LedInterpolation) EnumDescriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{7}
+}
+
+type LedColor struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enable bool `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Red   uint32 `protobuf:"varint,1,opt,name=red,proto3" json:"red,omitempty"`
+	Green uint32 `protobuf:"varint,2,opt,name=green,proto3" json:"green,omitempty"`
+	Blue  uint32 `protobuf:"varint,3,opt,name=blue,proto3" json:"blue,omitempty"`
 }
 
-func (x *StealthModeRequest) Reset() {
-	*x = StealthModeRequest{}
+func (x *LedColor) Reset() {
+	*x = LedColor{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -185,13 +450,13 @@ func (x *StealthModeRequest) Reset() {
 	}
 }
 
-func (x *StealthModeRequest) String() string {
+func (x *LedColor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StealthModeRequest) ProtoMessage() {}
+func (*LedColor) ProtoMessage() {}
 
-func (x *StealthModeRequest) ProtoReflect() protoreflect.Message {
+func (x *LedColor) ProtoReflect() protoreflect.Message {
 	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -203,28 +468,45 @@ func (x *StealthModeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StealthModeRequest.ProtoReflect.Descriptor instead.
-func (*StealthModeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LedColor.ProtoReflect.Descriptor instead.
+func (*LedColor) Descriptor() ([]byte, []int) {
 	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *StealthModeRequest) GetEnable() bool {
+func (x *LedColor) GetRed() uint32 {
 	if x != nil {
-		return x.Enable
+		return x.Red
 	}
-	return false
+	return 0
 }
 
-type SetFanSpeedRequest struct {
+func (x *LedColor) GetGreen() uint32 {
+	if x != nil {
+		return x.Green
+	}
+	return 0
+}
+
+func (x *LedColor) GetBlue() uint32 {
+	if x != nil {
+		return x.Blue
+	}
+	return 0
+}
+
+type LedKeyframe struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Percent int64 `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Color *LedColor `protobuf:"bytes,1,opt,name=color,proto3" json:"color,omitempty"`
+	// duration_ms is the time the color is held (step), or the time of the fade from the previous color (linear, ease)
+	DurationMs    int64            `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Interpolation LedInterpolation `protobuf:"varint,3,opt,name=interpolation,proto3,enum=api.bladeapi.v1alpha1.LedInterpolation" json:"interpolation,omitempty"`
 }
 
-func (x *SetFanSpeedRequest) Reset() {
-	*x = SetFanSpeedRequest{}
+func (x *LedKeyframe) Reset() {
+	*x = LedKeyframe{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -232,13 +514,13 @@ func (x *SetFanSpeedRequest) Reset() {
 	}
 }
 
-func (x *SetFanSpeedRequest) String() string {
+func (x *LedKeyframe) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetFanSpeedRequest) ProtoMessage() {}
+func (*LedKeyframe) ProtoMessage() {}
 
-func (x *SetFanSpeedRequest) ProtoReflect() protoreflect.Message {
+func (x *LedKeyframe) ProtoReflect() protoreflect.Message {
 	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -250,28 +532,51 @@ func (x *SetFanSpeedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetFanSpeedRequest.ProtoReflect.Descriptor instead.
-func (*SetFanSpeedRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LedKeyframe.ProtoReflect.Descriptor instead.
+func (*LedKeyframe) Descriptor() ([]byte, []int) {
 	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *SetFanSpeedRequest) GetPercent() int64 {
+func (x *LedKeyframe) GetColor() *LedColor {
 	if x != nil {
-		return x.Percent
+		return x.Color
+	}
+	return nil
+}
+
+func (x *LedKeyframe) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
 	}
 	return 0
 }
 
-type EmitEventRequest struct {
+func (x *LedKeyframe) GetInterpolation() LedInterpolation {
+	if x != nil {
+		return x.Interpolation
+	}
+	return LedInterpolation_LED_INTERPOLATION_STEP
+}
+
+type SetLedRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Event Event `protobuf:"varint,1,opt,name=event,proto3,enum=api.bladeapi.v1alpha1.Event" json:"event,omitempty"`
+	Led     LedIndex   `protobuf:"varint,1,opt,name=led,proto3,enum=api.bladeapi.v1alpha1.LedIndex" json:"led,omitempty"`
+	Pattern LedPattern `protobuf:"varint,2,opt,name=pattern,proto3,enum=api.bladeapi.v1alpha1.LedPattern" json:"pattern,omitempty"`
+	// color is the color shown by the pattern, e.g. while blinking
+	Color *LedColor `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	// base_color is the color shown in between, off if unset
+	BaseColor *LedColor `protobuf:"bytes,4,opt,name=base_color,json=baseColor,proto3" json:"base_color,omitempty"`
+	// keyframes are shown with LED_PATTERN_KEYFRAMES, repeating until the LED is cleared
+	Keyframes []*LedKeyframe `protobuf:"bytes,5,rep,name=keyframes,proto3" json:"keyframes,omitempty"`
+	// ttl_seconds is the time after which the LED returns to what it showed before, 0 keeps the pattern until it is cleared
+	TtlSeconds int64 `protobuf:"varint,6,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
 }
 
-func (x *EmitEventRequest) Reset() {
-	*x = EmitEventRequest{}
+func (x *SetLedRequest) Reset() {
+	*x = SetLedRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -279,13 +584,13 @@ func (x *EmitEventRequest) Reset() {
 	}
 }
 
-func (x *EmitEventRequest) String() string {
+func (x *SetLedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmitEventRequest) ProtoMessage() {}
+func (*SetLedRequest) ProtoMessage() {}
 
-func (x *EmitEventRequest) ProtoReflect() protoreflect.Message {
+func (x *SetLedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,44 +602,869 @@ func (x *EmitEventRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmitEventRequest.ProtoReflect.Descriptor instead.
-func (*EmitEventRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetLedRequest.ProtoReflect.Descriptor instead.
+func (*SetLedRequest) Descriptor() ([]byte, []int) {
 	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *EmitEventRequest) GetEvent() Event {
+func (x *SetLedRequest) GetLed() LedIndex {
+	if x != nil {
+		return x.Led
+	}
+	return LedIndex_LED_TOP
+}
+
+func (x *SetLedRequest) GetPattern() LedPattern {
+	if x != nil {
+		return x.Pattern
+	}
+	return LedPattern_LED_PATTERN_STATIC
+}
+
+func (x *SetLedRequest) GetColor() *LedColor {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+func (x *SetLedRequest) GetBaseColor() *LedColor {
+	if x != nil {
+		return x.BaseColor
+	}
+	return nil
+}
+
+func (x *SetLedRequest) GetKeyframes() []*LedKeyframe {
+	if x != nil {
+		return x.Keyframes
+	}
+	return nil
+}
+
+func (x *SetLedRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type ClearLedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Led LedIndex `protobuf:"varint,1,opt,name=led,proto3,enum=api.bladeapi.v1alpha1.LedIndex" json:"led,omitempty"`
+}
+
+func (x *ClearLedRequest) Reset() {
+	*x = ClearLedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearLedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearLedRequest) ProtoMessage() {}
+
+func (x *ClearLedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearLedRequest.ProtoReflect.Descriptor instead.
+func (*ClearLedRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClearLedRequest) GetLed() LedIndex {
+	if x != nil {
+		return x.Led
+	}
+	return LedIndex_LED_TOP
+}
+
+type SetLedBrightnessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// percent is the brightness all LED colors are scaled with, 0 turns off the LEDs
+	Percent uint32 `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+}
+
+func (x *SetLedBrightnessRequest) Reset() {
+	*x = SetLedBrightnessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLedBrightnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLedBrightnessRequest) ProtoMessage() {}
+
+func (x *SetLedBrightnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLedBrightnessRequest.ProtoReflect.Descriptor instead.
+func (*SetLedBrightnessRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetLedBrightnessRequest) GetPercent() uint32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type StealthModeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable bool `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+}
+
+func (x *StealthModeRequest) Reset() {
+	*x = StealthModeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StealthModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StealthModeRequest) ProtoMessage() {}
+
+func (x *StealthModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StealthModeRequest.ProtoReflect.Descriptor instead.
+func (*StealthModeRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StealthModeRequest) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+type SetFanSpeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Percent int64 `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	// duration_seconds is the lease time of the override, after which the fan speed is controlled automatically again.
+	// 0 keeps the override until it is removed.
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// owner identifies who set the override, e.g. user@host
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *SetFanSpeedRequest) Reset() {
+	*x = SetFanSpeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFanSpeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFanSpeedRequest) ProtoMessage() {}
+
+func (x *SetFanSpeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFanSpeedRequest.ProtoReflect.Descriptor instead.
+func (*SetFanSpeedRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetFanSpeedRequest) GetPercent() int64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *SetFanSpeedRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *SetFanSpeedRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type BoostFanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// percent is the fan speed the fan is raised to at least
+	Percent uint32 `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	// duration_seconds is the time the boost lasts
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+}
+
+func (x *BoostFanRequest) Reset() {
+	*x = BoostFanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoostFanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoostFanRequest) ProtoMessage() {}
+
+func (x *BoostFanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoostFanRequest.ProtoReflect.Descriptor instead.
+func (*BoostFanRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BoostFanRequest) GetPercent() uint32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *BoostFanRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type SetIdentifyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// duration_seconds is the time after which identify mode is cleared automatically, 0 keeps it until it is confirmed
+	DurationSeconds int64 `protobuf:"varint,1,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// pattern is shown on the edge LED, LED_PATTERN_BURST if unset. Keyframes are not supported.
+	Pattern *LedPattern `protobuf:"varint,2,opt,name=pattern,proto3,enum=api.bladeapi.v1alpha1.LedPattern,oneof" json:"pattern,omitempty"`
+	// color is the color of the pattern, the configured identify LED color if unset
+	Color *LedColor `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	// requester identifies who triggered identify mode, e.g. user@host
+	Requester string `protobuf:"bytes,4,opt,name=requester,proto3" json:"requester,omitempty"`
+}
+
+func (x *SetIdentifyRequest) Reset() {
+	*x = SetIdentifyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetIdentifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIdentifyRequest) ProtoMessage() {}
+
+func (x *SetIdentifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIdentifyRequest.ProtoReflect.Descriptor instead.
+func (*SetIdentifyRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetIdentifyRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *SetIdentifyRequest) GetPattern() LedPattern {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return LedPattern_LED_PATTERN_STATIC
+}
+
+func (x *SetIdentifyRequest) GetColor() *LedColor {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+func (x *SetIdentifyRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+type EmitEventRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event Event `protobuf:"varint,1,opt,name=event,proto3,enum=api.bladeapi.v1alpha1.Event" json:"event,omitempty"`
+	// identify customizes identify mode, only used with the IDENTIFY event
+	Identify *SetIdentifyRequest `protobuf:"bytes,2,opt,name=identify,proto3" json:"identify,omitempty"`
+}
+
+func (x *EmitEventRequest) Reset() {
+	*x = EmitEventRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmitEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitEventRequest) ProtoMessage() {}
+
+func (x *EmitEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitEventRequest.ProtoReflect.Descriptor instead.
+func (*EmitEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EmitEventRequest) GetEvent() Event {
+	if x != nil {
+		return x.Event
+	}
+	return Event_IDENTIFY
+}
+
+func (x *EmitEventRequest) GetIdentify() *SetIdentifyRequest {
+	if x != nil {
+		return x.Identify
+	}
+	return nil
+}
+
+type FanCurveStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Temperature int64  `protobuf:"varint,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Percent     uint32 `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+}
+
+func (x *FanCurveStep) Reset() {
+	*x = FanCurveStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FanCurveStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FanCurveStep) ProtoMessage() {}
+
+func (x *FanCurveStep) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FanCurveStep.ProtoReflect.Descriptor instead.
+func (*FanCurveStep) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FanCurveStep) GetTemperature() int64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *FanCurveStep) GetPercent() uint32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type SetFanCurveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps []*FanCurveStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	// persist writes the fan curve back to the agent configuration file so it survives restarts, clearing fan_profile
+	Persist bool `protobuf:"varint,2,opt,name=persist,proto3" json:"persist,omitempty"`
+}
+
+func (x *SetFanCurveRequest) Reset() {
+	*x = SetFanCurveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFanCurveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFanCurveRequest) ProtoMessage() {}
+
+func (x *SetFanCurveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFanCurveRequest.ProtoReflect.Descriptor instead.
+func (*SetFanCurveRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetFanCurveRequest) GetSteps() []*FanCurveStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *SetFanCurveRequest) GetPersist() bool {
+	if x != nil {
+		return x.Persist
+	}
+	return false
+}
+
+type SetFanProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SetFanProfileRequest) Reset() {
+	*x = SetFanProfileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFanProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFanProfileRequest) ProtoMessage() {}
+
+func (x *SetFanProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFanProfileRequest.ProtoReflect.Descriptor instead.
+func (*SetFanProfileRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetFanProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FanCurveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps                        []*FanCurveStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	CriticalTemperatureThreshold int64           `protobuf:"varint,2,opt,name=critical_temperature_threshold,json=criticalTemperatureThreshold,proto3" json:"critical_temperature_threshold,omitempty"`
+}
+
+func (x *FanCurveResponse) Reset() {
+	*x = FanCurveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FanCurveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FanCurveResponse) ProtoMessage() {}
+
+func (x *FanCurveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FanCurveResponse.ProtoReflect.Descriptor instead.
+func (*FanCurveResponse) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FanCurveResponse) GetSteps() []*FanCurveStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *FanCurveResponse) GetCriticalTemperatureThreshold() int64 {
+	if x != nil {
+		return x.CriticalTemperatureThreshold
+	}
+	return 0
+}
+
+type ScheduleTransition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// time is the time of the transition as UNIX timestamp
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// fan_profile is the fan profile activated by the transition, empty if unchanged
+	FanProfile string `protobuf:"bytes,2,opt,name=fan_profile,json=fanProfile,proto3" json:"fan_profile,omitempty"`
+	// stealth_mode is the stealth mode applied by the transition, unset if unchanged
+	StealthMode *bool `protobuf:"varint,3,opt,name=stealth_mode,json=stealthMode,proto3,oneof" json:"stealth_mode,omitempty"`
+	// led_brightness is the LED brightness in percent applied by the transition, unset if unchanged
+	LedBrightness *uint32 `protobuf:"varint,4,opt,name=led_brightness,json=ledBrightness,proto3,oneof" json:"led_brightness,omitempty"`
+}
+
+func (x *ScheduleTransition) Reset() {
+	*x = ScheduleTransition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTransition) ProtoMessage() {}
+
+func (x *ScheduleTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTransition.ProtoReflect.Descriptor instead.
+func (*ScheduleTransition) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScheduleTransition) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ScheduleTransition) GetFanProfile() string {
+	if x != nil {
+		return x.FanProfile
+	}
+	return ""
+}
+
+func (x *ScheduleTransition) GetStealthMode() bool {
+	if x != nil && x.StealthMode != nil {
+		return *x.StealthMode
+	}
+	return false
+}
+
+func (x *ScheduleTransition) GetLedBrightness() uint32 {
+	if x != nil && x.LedBrightness != nil {
+		return *x.LedBrightness
+	}
+	return 0
+}
+
+type CalibrateFanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// steps are the duty cycles (in percent) to measure, empty to use the steps from the agent configuration
+	Steps []uint32 `protobuf:"varint,1,rep,packed,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *CalibrateFanRequest) Reset() {
+	*x = CalibrateFanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalibrateFanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalibrateFanRequest) ProtoMessage() {}
+
+func (x *CalibrateFanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalibrateFanRequest.ProtoReflect.Descriptor instead.
+func (*CalibrateFanRequest) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CalibrateFanRequest) GetSteps() []uint32 {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type FanCalibrationPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Percent uint32 `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Rpm     int64  `protobuf:"varint,2,opt,name=rpm,proto3" json:"rpm,omitempty"`
+}
+
+func (x *FanCalibrationPoint) Reset() {
+	*x = FanCalibrationPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FanCalibrationPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FanCalibrationPoint) ProtoMessage() {}
+
+func (x *FanCalibrationPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FanCalibrationPoint.ProtoReflect.Descriptor instead.
+func (*FanCalibrationPoint) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FanCalibrationPoint) GetPercent() uint32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *FanCalibrationPoint) GetRpm() int64 {
 	if x != nil {
-		return x.Event
+		return x.Rpm
 	}
-	return Event_IDENTIFY
+	return 0
 }
 
-type FanCurveStep struct {
+type CalibrateFanResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Temperature int64  `protobuf:"varint,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
-	Percent     uint32 `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	Points []*FanCalibrationPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	// spin_up_percent is the lowest duty cycle that starts a standing fan
+	SpinUpPercent uint32 `protobuf:"varint,2,opt,name=spin_up_percent,json=spinUpPercent,proto3" json:"spin_up_percent,omitempty"`
+	// min_running_percent is the lowest duty cycle that keeps a spinning fan from stalling
+	MinRunningPercent uint32 `protobuf:"varint,3,opt,name=min_running_percent,json=minRunningPercent,proto3" json:"min_running_percent,omitempty"`
 }
 
-func (x *FanCurveStep) Reset() {
-	*x = FanCurveStep{}
+func (x *CalibrateFanResponse) Reset() {
+	*x = CalibrateFanResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[3]
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FanCurveStep) String() string {
+func (x *CalibrateFanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FanCurveStep) ProtoMessage() {}
+func (*CalibrateFanResponse) ProtoMessage() {}
 
-func (x *FanCurveStep) ProtoReflect() protoreflect.Message {
-	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[3]
+func (x *CalibrateFanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,21 +1475,28 @@ func (x *FanCurveStep) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FanCurveStep.ProtoReflect.Descriptor instead.
-func (*FanCurveStep) Descriptor() ([]byte, []int) {
-	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CalibrateFanResponse.ProtoReflect.Descriptor instead.
+func (*CalibrateFanResponse) Descriptor() ([]byte, []int) {
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *FanCurveStep) GetTemperature() int64 {
+func (x *CalibrateFanResponse) GetPoints() []*FanCalibrationPoint {
 	if x != nil {
-		return x.Temperature
+		return x.Points
+	}
+	return nil
+}
+
+func (x *CalibrateFanResponse) GetSpinUpPercent() uint32 {
+	if x != nil {
+		return x.SpinUpPercent
 	}
 	return 0
 }
 
-func (x *FanCurveStep) GetPercent() uint32 {
+func (x *CalibrateFanResponse) GetMinRunningPercent() uint32 {
 	if x != nil {
-		return x.Percent
+		return x.MinRunningPercent
 	}
 	return 0
 }
@@ -377,7 +1514,7 @@ type VersionInfo struct {
 func (x *VersionInfo) Reset() {
 	*x = VersionInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[4]
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -390,7 +1527,7 @@ func (x *VersionInfo) String() string {
 func (*VersionInfo) ProtoMessage() {}
 
 func (x *VersionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[4]
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -403,7 +1540,7 @@ func (x *VersionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionInfo.ProtoReflect.Descriptor instead.
 func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{4}
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *VersionInfo) GetVersion() string {
@@ -443,12 +1580,38 @@ type StatusResponse struct {
 	CriticalTemperatureThreshold int64           `protobuf:"varint,9,opt,name=critical_temperature_threshold,json=criticalTemperatureThreshold,proto3" json:"critical_temperature_threshold,omitempty"`
 	FanCurveSteps                []*FanCurveStep `protobuf:"bytes,10,rep,name=fan_curve_steps,json=fanCurveSteps,proto3" json:"fan_curve_steps,omitempty"`
 	Version                      *VersionInfo    `protobuf:"bytes,11,opt,name=version,proto3" json:"version,omitempty"`
+	// fan_profile is the name of the active fan profile, empty if the fan curve was not set by a profile
+	FanProfile string `protobuf:"bytes,12,opt,name=fan_profile,json=fanProfile,proto3" json:"fan_profile,omitempty"`
+	// next_schedule_transition is the next upcoming schedule transition, unset if there is none
+	NextScheduleTransition *ScheduleTransition `protobuf:"bytes,13,opt,name=next_schedule_transition,json=nextScheduleTransition,proto3" json:"next_schedule_transition,omitempty"`
+	// fan_failure is the fan failure detected by the fan health monitor
+	FanFailure FanFailure `protobuf:"varint,14,opt,name=fan_failure,json=fanFailure,proto3,enum=api.bladeapi.v1alpha1.FanFailure" json:"fan_failure,omitempty"`
+	// fan_override_owner is the owner of the active fan speed override
+	FanOverrideOwner string `protobuf:"bytes,15,opt,name=fan_override_owner,json=fanOverrideOwner,proto3" json:"fan_override_owner,omitempty"`
+	// fan_override_remaining_seconds is the lease time left on the active fan speed override, 0 if it doesn't expire
+	FanOverrideRemainingSeconds int64 `protobuf:"varint,16,opt,name=fan_override_remaining_seconds,json=fanOverrideRemainingSeconds,proto3" json:"fan_override_remaining_seconds,omitempty"`
+	// fan_boost_percent is the fan speed the highest active fan boost raises the fan to, 0 if no boost is active
+	FanBoostPercent uint32 `protobuf:"varint,17,opt,name=fan_boost_percent,json=fanBoostPercent,proto3" json:"fan_boost_percent,omitempty"`
+	// fan_boost_remaining_seconds is the time left on the highest active fan boost
+	FanBoostRemainingSeconds int64 `protobuf:"varint,18,opt,name=fan_boost_remaining_seconds,json=fanBoostRemainingSeconds,proto3" json:"fan_boost_remaining_seconds,omitempty"`
+	// led_brightness_percent is the brightness all LED colors are scaled with
+	LedBrightnessPercent uint32 `protobuf:"varint,19,opt,name=led_brightness_percent,json=ledBrightnessPercent,proto3" json:"led_brightness_percent,omitempty"`
+	// faults are the active fault conditions, ordered by blink code
+	Faults []Fault `protobuf:"varint,20,rep,packed,name=faults,proto3,enum=api.bladeapi.v1alpha1.Fault" json:"faults,omitempty"`
+	// identify_requester is who triggered the active identify mode
+	IdentifyRequester string `protobuf:"bytes,21,opt,name=identify_requester,json=identifyRequester,proto3" json:"identify_requester,omitempty"`
+	// identify_since is the unix time identify mode was triggered, 0 if identify mode is not active
+	IdentifySince int64 `protobuf:"varint,22,opt,name=identify_since,json=identifySince,proto3" json:"identify_since,omitempty"`
+	// identify_remaining_seconds is the time left until identify mode is cleared automatically, 0 if it doesn't expire
+	IdentifyRemainingSeconds int64 `protobuf:"varint,23,opt,name=identify_remaining_seconds,json=identifyRemainingSeconds,proto3" json:"identify_remaining_seconds,omitempty"`
+	// compute_module is the model of the compute module as detected by the HAL
+	ComputeModule string `protobuf:"bytes,24,opt,name=compute_module,json=computeModule,proto3" json:"compute_module,omitempty"`
 }
 
 func (x *StatusResponse) Reset() {
 	*x = StatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[5]
+		mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -461,7 +1624,7 @@ func (x *StatusResponse) String() string {
 func (*StatusResponse) ProtoMessage() {}
 
 func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[5]
+	mi := &file_api_bladeapi_v1alpha1_blade_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +1637,7 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{5}
+	return file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *StatusResponse) GetStealthMode() bool {
@@ -554,6 +1717,97 @@ func (x *StatusResponse) GetVersion() *VersionInfo {
 	return nil
 }
 
+func (x *StatusResponse) GetFanProfile() string {
+	if x != nil {
+		return x.FanProfile
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetNextScheduleTransition() *ScheduleTransition {
+	if x != nil {
+		return x.NextScheduleTransition
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetFanFailure() FanFailure {
+	if x != nil {
+		return x.FanFailure
+	}
+	return FanFailure_FAN_FAILURE_NONE
+}
+
+func (x *StatusResponse) GetFanOverrideOwner() string {
+	if x != nil {
+		return x.FanOverrideOwner
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetFanOverrideRemainingSeconds() int64 {
+	if x != nil {
+		return x.FanOverrideRemainingSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetFanBoostPercent() uint32 {
+	if x != nil {
+		return x.FanBoostPercent
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetFanBoostRemainingSeconds() int64 {
+	if x != nil {
+		return x.FanBoostRemainingSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetLedBrightnessPercent() uint32 {
+	if x != nil {
+		return x.LedBrightnessPercent
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetFaults() []Fault {
+	if x != nil {
+		return x.Faults
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetIdentifyRequester() string {
+	if x != nil {
+		return x.IdentifyRequester
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetIdentifySince() int64 {
+	if x != nil {
+		return x.IdentifySince
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetIdentifyRemainingSeconds() int64 {
+	if x != nil {
+		return x.IdentifyRemainingSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetComputeModule() string {
+	if x != nil {
+		return x.ComputeModule
+	}
+	return ""
+}
+
 var File_api_bladeapi_v1alpha1_blade_proto protoreflect.FileDescriptor
 
 var file_api_bladeapi_v1alpha1_blade_proto_rawDesc = []byte{
@@ -562,77 +1816,285 @@ var file_api_bladeapi_v1alpha1_blade_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x12, 0x15, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70,
 	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2c, 0x0a, 0x12, 0x53, 0x74, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x2e, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x53,
-	0x70, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70,
-	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65,
-	0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x46, 0x0a, 0x10, 0x45, 0x6d, 0x69, 0x74, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62,
-	0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x4a, 0x0a,
-	0x0c, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x12, 0x20, 0x0a,
-	0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x53, 0x0a, 0x0b, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x22, 0xa9,
-	0x04, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x6d, 0x6f, 0x64,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79,
-	0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x27, 0x0a,
-	0x0f, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c,
-	0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x65, 0x6d,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x61, 0x6e, 0x5f,
-	0x72, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x6e, 0x52, 0x70,
-	0x6d, 0x12, 0x45, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x46, 0x0a, 0x08, 0x4c, 0x65, 0x64, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x62,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x6c, 0x75, 0x65, 0x22,
+	0xb4, 0x01, 0x0a, 0x0b, 0x4c, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12,
+	0x35, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52,
+	0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x4d, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x70, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x70,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xd9, 0x02, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4c, 0x65,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x03, 0x6c, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64,
+	0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65,
+	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x03, 0x6c, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x35, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c,
 	0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x70, 0x6f, 0x77,
-	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x6e, 0x5f,
-	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x66,
-	0x61, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x61, 0x6e,
-	0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x66, 0x61, 0x6e, 0x53, 0x70, 0x65, 0x65, 0x64,
-	0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x44, 0x0a, 0x1e, 0x63, 0x72, 0x69,
-	0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x1c, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x54, 0x65, 0x6d, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
-	0x4b, 0x0a, 0x0f, 0x66, 0x61, 0x6e, 0x5f, 0x63, 0x75, 0x72, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x65,
-	0x70, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62,
-	0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52, 0x0d, 0x66,
-	0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3c, 0x0a, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x4c, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x3e, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65, 0x64, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x09, 0x62, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x40, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65, 0x64, 0x4b, 0x65,
+	0x79, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x66, 0x72, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x22, 0x44, 0x0a, 0x0f, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4c, 0x65, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x03, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x65, 0x64, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x52, 0x03, 0x6c, 0x65, 0x64, 0x22, 0x33, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x4c,
+	0x65, 0x64, 0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x0a,
+	0x12, 0x53, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x6f, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x46, 0x61, 0x6e, 0x53, 0x70, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x56, 0x0a, 0x0f,
+	0x42, 0x6f, 0x6f, 0x73, 0x74, 0x46, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x40, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c,
+	0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x4c, 0x65, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c,
+	0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x4c, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x10, 0x45, 0x6d,
+	0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32,
+	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x45, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x22, 0x4a, 0x0a, 0x0c, 0x46, 0x61, 0x6e,
+	0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x69, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x43,
+	0x75, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x05, 0x73,
+	0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74,
+	0x22, 0x2a, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x93, 0x01, 0x0a,
+	0x10, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76,
+	0x65, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x44, 0x0a, 0x1e,
+	0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x1c, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x54, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x22, 0xc1, 0x01, 0x0a, 0x12, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x66, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x66, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x26,
+	0x0a, 0x0c, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x4d,
+	0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01,
+	0x52, 0x0d, 0x6c, 0x65, 0x64, 0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x88,
+	0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x2b, 0x0a, 0x13, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72,
+	0x61, 0x74, 0x65, 0x46, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74,
+	0x65, 0x70, 0x73, 0x22, 0x41, 0x0a, 0x13, 0x46, 0x61, 0x6e, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x72, 0x70, 0x6d, 0x22, 0xb2, 0x01, 0x0a, 0x14, 0x43, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x61, 0x74, 0x65, 0x46, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x42, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x61, 0x6e, 0x43, 0x61, 0x6c, 0x69, 0x62,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x70, 0x69, 0x6e, 0x5f, 0x75, 0x70, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x70,
+	0x69, 0x6e, 0x55, 0x70, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6d,
+	0x69, 0x6e, 0x5f, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x52, 0x75, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x53, 0x0a, 0x0b, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
+	0x22, 0xf8, 0x09, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x74, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x79, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63,
+	0x61, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x61,
+	0x6e, 0x5f, 0x72, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x6e,
+	0x52, 0x70, 0x6d, 0x12, 0x45, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x70,
+	0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61,
+	0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x66, 0x61, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x66,
+	0x61, 0x6e, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74,
+	0x69, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x66, 0x61, 0x6e, 0x53, 0x70, 0x65,
+	0x65, 0x64, 0x41, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x44, 0x0a, 0x1e, 0x63,
+	0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x1c, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x54, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x12, 0x4b, 0x0a, 0x0f, 0x66, 0x61, 0x6e, 0x5f, 0x63, 0x75, 0x72, 0x76, 0x65, 0x5f, 0x73,
+	0x74, 0x65, 0x70, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52,
+	0x0d, 0x66, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3c,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b,
+	0x66, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x66, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x63, 0x0a,
+	0x18, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x16, 0x6e, 0x65, 0x78, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x0b, 0x66, 0x61, 0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c,
+	0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x46, 0x61, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x66, 0x61, 0x6e, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x61, 0x6e, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x66, 0x61, 0x6e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x1e, 0x66, 0x61, 0x6e, 0x5f, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x1b, 0x66, 0x61,
+	0x6e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69,
+	0x6e, 0x67, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x61, 0x6e,
+	0x5f, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x66, 0x61, 0x6e, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3d, 0x0a, 0x1b, 0x66, 0x61, 0x6e, 0x5f, 0x62, 0x6f, 0x6f,
+	0x73, 0x74, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x18, 0x66, 0x61, 0x6e, 0x42,
+	0x6f, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6c, 0x65, 0x64, 0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e,
+	0x65, 0x73, 0x73, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x2d, 0x0a, 0x12, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x79, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x79, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x18, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x5f,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2a, 0x4d, 0x0a, 0x05, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x46, 0x59,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x46, 0x59, 0x5f, 0x43,
+	0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x52, 0x49, 0x54,
+	0x49, 0x43, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43,
+	0x41, 0x4c, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x54, 0x10, 0x03, 0x2a, 0x21, 0x0a, 0x07, 0x46, 0x61,
+	0x6e, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x4d, 0x41, 0x52, 0x54, 0x10, 0x01, 0x2a, 0x79, 0x0a,
+	0x0a, 0x46, 0x61, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x46,
+	0x41, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10,
+	0x00, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45,
+	0x5f, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x46, 0x41, 0x4e, 0x5f,
+	0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x54, 0x41, 0x43, 0x48, 0x5f, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x46, 0x41, 0x4e, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x50, 0x4d, 0x5f, 0x4f, 0x55, 0x54, 0x5f, 0x4f, 0x46,
+	0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x03, 0x2a, 0x8c, 0x01, 0x0a, 0x05, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x5f, 0x54, 0x45, 0x4d, 0x50, 0x45, 0x52, 0x41, 0x54, 0x55, 0x52, 0x45, 0x5f, 0x53,
+	0x45, 0x4e, 0x53, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x55, 0x4c, 0x54,
+	0x5f, 0x46, 0x41, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x10, 0x02, 0x12, 0x20,
+	0x0a, 0x1c, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x53, 0x4d, 0x41, 0x52, 0x54, 0x5f, 0x46, 0x41,
+	0x4e, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x03,
+	0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46,
+	0x49, 0x43, 0x41, 0x54, 0x45, 0x10, 0x04, 0x2a, 0x2e, 0x0a, 0x0b, 0x50, 0x6f, 0x77, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4f, 0x45, 0x5f, 0x4f, 0x52,
+	0x5f, 0x55, 0x53, 0x42, 0x43, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x4f, 0x45, 0x5f, 0x38,
+	0x30, 0x32, 0x5f, 0x41, 0x54, 0x10, 0x01, 0x2a, 0x25, 0x0a, 0x08, 0x4c, 0x65, 0x64, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x45, 0x44, 0x5f, 0x54, 0x4f, 0x50, 0x10, 0x00,
+	0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x45, 0x44, 0x5f, 0x45, 0x44, 0x47, 0x45, 0x10, 0x01, 0x2a, 0x8d,
+	0x01, 0x0a, 0x0a, 0x4c, 0x65, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a,
+	0x12, 0x4c, 0x45, 0x44, 0x5f, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x49, 0x43, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x45, 0x44, 0x5f, 0x50, 0x41, 0x54,
+	0x54, 0x45, 0x52, 0x4e, 0x5f, 0x53, 0x4c, 0x4f, 0x57, 0x5f, 0x42, 0x4c, 0x49, 0x4e, 0x4b, 0x10,
+	0x01, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x45, 0x44, 0x5f, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e,
+	0x5f, 0x42, 0x55, 0x52, 0x53, 0x54, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x45, 0x44, 0x5f,
+	0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x5f, 0x42, 0x52, 0x45, 0x41, 0x54, 0x48, 0x49, 0x4e,
+	0x47, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x45, 0x44, 0x5f, 0x50, 0x41, 0x54, 0x54, 0x45,
+	0x52, 0x4e, 0x5f, 0x4b, 0x45, 0x59, 0x46, 0x52, 0x41, 0x4d, 0x45, 0x53, 0x10, 0x04, 0x2a, 0x68,
+	0x0a, 0x10, 0x4c, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x45, 0x44, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x50,
+	0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x10, 0x00, 0x12, 0x1c,
+	0x0a, 0x18, 0x4c, 0x45, 0x44, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x50, 0x4f, 0x4c, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x4e, 0x45, 0x41, 0x52, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16,
+	0x4c, 0x45, 0x44, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x50, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x45, 0x41, 0x53, 0x45, 0x10, 0x02, 0x32, 0xee, 0x09, 0x0a, 0x11, 0x42, 0x6c, 0x61,
+	0x64, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e,
+	0x0a, 0x09, 0x45, 0x6d, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x52,
+	0x0a, 0x0b, 0x53, 0x65, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x12, 0x29, 0x2e,
 	0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x2a, 0x4d, 0x0a, 0x05, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x46, 0x59, 0x10,
-	0x00, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x4f,
-	0x4e, 0x46, 0x49, 0x52, 0x4d, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x52, 0x49, 0x54, 0x49,
-	0x43, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41,
-	0x4c, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x54, 0x10, 0x03, 0x2a, 0x21, 0x0a, 0x07, 0x46, 0x61, 0x6e,
-	0x55, 0x6e, 0x69, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10,
-	0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x4d, 0x41, 0x52, 0x54, 0x10, 0x01, 0x2a, 0x2e, 0x0a, 0x0b,
-	0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0f, 0x0a, 0x0b, 0x50,
-	0x4f, 0x45, 0x5f, 0x4f, 0x52, 0x5f, 0x55, 0x53, 0x42, 0x43, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a,
-	0x50, 0x4f, 0x45, 0x5f, 0x38, 0x30, 0x32, 0x5f, 0x41, 0x54, 0x10, 0x01, 0x32, 0xed, 0x03, 0x0a,
-	0x11, 0x42, 0x6c, 0x61, 0x64, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x4e, 0x0a, 0x09, 0x45, 0x6d, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
-	0x27, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
 	0x22, 0x00, 0x12, 0x4a, 0x0a, 0x16, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x49, 0x64, 0x65,
 	0x6e, 0x74, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x16, 0x2e, 0x67,
@@ -658,13 +2120,56 @@ var file_api_bladeapi_v1alpha1_blade_proto_rawDesc = []byte{
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
 	0x70, 0x74, 0x79, 0x1a, 0x25, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61,
 	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x57, 0x5a, 0x55,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x70, 0x74, 0x69, 0x6d,
-	0x65, 0x2d, 0x69, 0x6e, 0x64, 0x75, 0x65, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x63, 0x6f,
-	0x6d, 0x70, 0x75, 0x74, 0x65, 0x2d, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x2d, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x3b, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0b,
+	0x53, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x12, 0x29, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c,
+	0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x46, 0x61, 0x6e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x56, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x46, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x46,
+	0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x69, 0x0a, 0x0c, 0x43, 0x61,
+	0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x65, 0x46, 0x61, 0x6e, 0x12, 0x2a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x65, 0x46, 0x61, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61,
+	0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x65, 0x46, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x08, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x46, 0x61,
+	0x6e, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x46,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x12, 0x24, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4c, 0x0a,
+	0x08, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4c, 0x65, 0x64, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x10, 0x53,
+	0x65, 0x74, 0x4c, 0x65, 0x64, 0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12,
+	0x2e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x64, 0x42, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x57, 0x5a, 0x55, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x2d, 0x69,
+	0x6e, 0x64, 0x75, 0x65, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x2d, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x2d, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x3b, 0x62, 0x6c, 0x61, 0x64, 0x65, 0x61, 0x70, 0x69, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -679,42 +2184,96 @@ func file_api_bladeapi_v1alpha1_blade_proto_rawDescGZIP() []byte {
 	return file_api_bladeapi_v1alpha1_blade_proto_rawDescData
 }
 
-var file_api_bladeapi_v1alpha1_blade_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_bladeapi_v1alpha1_blade_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_bladeapi_v1alpha1_blade_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_bladeapi_v1alpha1_blade_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_api_bladeapi_v1alpha1_blade_proto_goTypes = []interface{}{
-	(Event)(0),                 // 0: api.bladeapi.v1alpha1.Event
-	(FanUnit)(0),               // 1: api.bladeapi.v1alpha1.FanUnit
-	(PowerStatus)(0),           // 2: api.bladeapi.v1alpha1.PowerStatus
-	(*StealthModeRequest)(nil), // 3: api.bladeapi.v1alpha1.StealthModeRequest
-	(*SetFanSpeedRequest)(nil), // 4: api.bladeapi.v1alpha1.SetFanSpeedRequest
-	(*EmitEventRequest)(nil),   // 5: api.bladeapi.v1alpha1.EmitEventRequest
-	(*FanCurveStep)(nil),       // 6: api.bladeapi.v1alpha1.FanCurveStep
-	(*VersionInfo)(nil),        // 7: api.bladeapi.v1alpha1.VersionInfo
-	(*StatusResponse)(nil),     // 8: api.bladeapi.v1alpha1.StatusResponse
-	(*emptypb.Empty)(nil),      // 9: google.protobuf.Empty
+	(Event)(0),                      // 0: api.bladeapi.v1alpha1.Event
+	(FanUnit)(0),                    // 1: api.bladeapi.v1alpha1.FanUnit
+	(FanFailure)(0),                 // 2: api.bladeapi.v1alpha1.FanFailure
+	(Fault)(0),                      // 3: api.bladeapi.v1alpha1.Fault
+	(PowerStatus)(0),                // 4: api.bladeapi.v1alpha1.PowerStatus
+	(LedIndex)(0),                   // 5: api.bladeapi.v1alpha1.LedIndex
+	(LedPattern)(0),                 // 6: api.bladeapi.v1alpha1.LedPattern
+	(LedInterpolation)(0),           // 7: api.bladeapi.v1alpha1.LedInterpolation
+	(*LedColor)(nil),                // 8: api.bladeapi.v1alpha1.LedColor
+	(*LedKeyframe)(nil),             // 9: api.bladeapi.v1alpha1.LedKeyframe
+	(*SetLedRequest)(nil),           // 10: api.bladeapi.v1alpha1.SetLedRequest
+	(*ClearLedRequest)(nil),         // 11: api.bladeapi.v1alpha1.ClearLedRequest
+	(*SetLedBrightnessRequest)(nil), // 12: api.bladeapi.v1alpha1.SetLedBrightnessRequest
+	(*StealthModeRequest)(nil),      // 13: api.bladeapi.v1alpha1.StealthModeRequest
+	(*SetFanSpeedRequest)(nil),      // 14: api.bladeapi.v1alpha1.SetFanSpeedRequest
+	(*BoostFanRequest)(nil),         // 15: api.bladeapi.v1alpha1.BoostFanRequest
+	(*SetIdentifyRequest)(nil),      // 16: api.bladeapi.v1alpha1.SetIdentifyRequest
+	(*EmitEventRequest)(nil),        // 17: api.bladeapi.v1alpha1.EmitEventRequest
+	(*FanCurveStep)(nil),            // 18: api.bladeapi.v1alpha1.FanCurveStep
+	(*SetFanCurveRequest)(nil),      // 19: api.bladeapi.v1alpha1.SetFanCurveRequest
+	(*SetFanProfileRequest)(nil),    // 20: api.bladeapi.v1alpha1.SetFanProfileRequest
+	(*FanCurveResponse)(nil),        // 21: api.bladeapi.v1alpha1.FanCurveResponse
+	(*ScheduleTransition)(nil),      // 22: api.bladeapi.v1alpha1.ScheduleTransition
+	(*CalibrateFanRequest)(nil),     // 23: api.bladeapi.v1alpha1.CalibrateFanRequest
+	(*FanCalibrationPoint)(nil),     // 24: api.bladeapi.v1alpha1.FanCalibrationPoint
+	(*CalibrateFanResponse)(nil),    // 25: api.bladeapi.v1alpha1.CalibrateFanResponse
+	(*VersionInfo)(nil),             // 26: api.bladeapi.v1alpha1.VersionInfo
+	(*StatusResponse)(nil),          // 27: api.bladeapi.v1alpha1.StatusResponse
+	(*emptypb.Empty)(nil),           // 28: google.protobuf.Empty
 }
 var file_api_bladeapi_v1alpha1_blade_proto_depIdxs = []int32{
-	0,  // 0: api.bladeapi.v1alpha1.EmitEventRequest.event:type_name -> api.bladeapi.v1alpha1.Event
-	2,  // 1: api.bladeapi.v1alpha1.StatusResponse.power_status:type_name -> api.bladeapi.v1alpha1.PowerStatus
-	6,  // 2: api.bladeapi.v1alpha1.StatusResponse.fan_curve_steps:type_name -> api.bladeapi.v1alpha1.FanCurveStep
-	7,  // 3: api.bladeapi.v1alpha1.StatusResponse.version:type_name -> api.bladeapi.v1alpha1.VersionInfo
-	5,  // 4: api.bladeapi.v1alpha1.BladeAgentService.EmitEvent:input_type -> api.bladeapi.v1alpha1.EmitEventRequest
-	9,  // 5: api.bladeapi.v1alpha1.BladeAgentService.WaitForIdentifyConfirm:input_type -> google.protobuf.Empty
-	4,  // 6: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeed:input_type -> api.bladeapi.v1alpha1.SetFanSpeedRequest
-	9,  // 7: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeedAuto:input_type -> google.protobuf.Empty
-	3,  // 8: api.bladeapi.v1alpha1.BladeAgentService.SetStealthMode:input_type -> api.bladeapi.v1alpha1.StealthModeRequest
-	9,  // 9: api.bladeapi.v1alpha1.BladeAgentService.GetStatus:input_type -> google.protobuf.Empty
-	9,  // 10: api.bladeapi.v1alpha1.BladeAgentService.EmitEvent:output_type -> google.protobuf.Empty
-	9,  // 11: api.bladeapi.v1alpha1.BladeAgentService.WaitForIdentifyConfirm:output_type -> google.protobuf.Empty
-	9,  // 12: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeed:output_type -> google.protobuf.Empty
-	9,  // 13: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeedAuto:output_type -> google.protobuf.Empty
-	9,  // 14: api.bladeapi.v1alpha1.BladeAgentService.SetStealthMode:output_type -> google.protobuf.Empty
-	8,  // 15: api.bladeapi.v1alpha1.BladeAgentService.GetStatus:output_type -> api.bladeapi.v1alpha1.StatusResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	8,  // 0: api.bladeapi.v1alpha1.LedKeyframe.color:type_name -> api.bladeapi.v1alpha1.LedColor
+	7,  // 1: api.bladeapi.v1alpha1.LedKeyframe.interpolation:type_name -> api.bladeapi.v1alpha1.LedInterpolation
+	5,  // 2: api.bladeapi.v1alpha1.SetLedRequest.led:type_name -> api.bladeapi.v1alpha1.LedIndex
+	6,  // 3: api.bladeapi.v1alpha1.SetLedRequest.pattern:type_name -> api.bladeapi.v1alpha1.LedPattern
+	8,  // 4: api.bladeapi.v1alpha1.SetLedRequest.color:type_name -> api.bladeapi.v1alpha1.LedColor
+	8,  // 5: api.bladeapi.v1alpha1.SetLedRequest.base_color:type_name -> api.bladeapi.v1alpha1.LedColor
+	9,  // 6: api.bladeapi.v1alpha1.SetLedRequest.keyframes:type_name -> api.bladeapi.v1alpha1.LedKeyframe
+	5,  // 7: api.bladeapi.v1alpha1.ClearLedRequest.led:type_name -> api.bladeapi.v1alpha1.LedIndex
+	6,  // 8: api.bladeapi.v1alpha1.SetIdentifyRequest.pattern:type_name -> api.bladeapi.v1alpha1.LedPattern
+	8,  // 9: api.bladeapi.v1alpha1.SetIdentifyRequest.color:type_name -> api.bladeapi.v1alpha1.LedColor
+	0,  // 10: api.bladeapi.v1alpha1.EmitEventRequest.event:type_name -> api.bladeapi.v1alpha1.Event
+	16, // 11: api.bladeapi.v1alpha1.EmitEventRequest.identify:type_name -> api.bladeapi.v1alpha1.SetIdentifyRequest
+	18, // 12: api.bladeapi.v1alpha1.SetFanCurveRequest.steps:type_name -> api.bladeapi.v1alpha1.FanCurveStep
+	18, // 13: api.bladeapi.v1alpha1.FanCurveResponse.steps:type_name -> api.bladeapi.v1alpha1.FanCurveStep
+	24, // 14: api.bladeapi.v1alpha1.CalibrateFanResponse.points:type_name -> api.bladeapi.v1alpha1.FanCalibrationPoint
+	4,  // 15: api.bladeapi.v1alpha1.StatusResponse.power_status:type_name -> api.bladeapi.v1alpha1.PowerStatus
+	18, // 16: api.bladeapi.v1alpha1.StatusResponse.fan_curve_steps:type_name -> api.bladeapi.v1alpha1.FanCurveStep
+	26, // 17: api.bladeapi.v1alpha1.StatusResponse.version:type_name -> api.bladeapi.v1alpha1.VersionInfo
+	22, // 18: api.bladeapi.v1alpha1.StatusResponse.next_schedule_transition:type_name -> api.bladeapi.v1alpha1.ScheduleTransition
+	2,  // 19: api.bladeapi.v1alpha1.StatusResponse.fan_failure:type_name -> api.bladeapi.v1alpha1.FanFailure
+	3,  // 20: api.bladeapi.v1alpha1.StatusResponse.faults:type_name -> api.bladeapi.v1alpha1.Fault
+	17, // 21: api.bladeapi.v1alpha1.BladeAgentService.EmitEvent:input_type -> api.bladeapi.v1alpha1.EmitEventRequest
+	16, // 22: api.bladeapi.v1alpha1.BladeAgentService.SetIdentify:input_type -> api.bladeapi.v1alpha1.SetIdentifyRequest
+	28, // 23: api.bladeapi.v1alpha1.BladeAgentService.WaitForIdentifyConfirm:input_type -> google.protobuf.Empty
+	14, // 24: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeed:input_type -> api.bladeapi.v1alpha1.SetFanSpeedRequest
+	28, // 25: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeedAuto:input_type -> google.protobuf.Empty
+	13, // 26: api.bladeapi.v1alpha1.BladeAgentService.SetStealthMode:input_type -> api.bladeapi.v1alpha1.StealthModeRequest
+	28, // 27: api.bladeapi.v1alpha1.BladeAgentService.GetStatus:input_type -> google.protobuf.Empty
+	19, // 28: api.bladeapi.v1alpha1.BladeAgentService.SetFanCurve:input_type -> api.bladeapi.v1alpha1.SetFanCurveRequest
+	28, // 29: api.bladeapi.v1alpha1.BladeAgentService.GetFanCurve:input_type -> google.protobuf.Empty
+	20, // 30: api.bladeapi.v1alpha1.BladeAgentService.SetFanProfile:input_type -> api.bladeapi.v1alpha1.SetFanProfileRequest
+	23, // 31: api.bladeapi.v1alpha1.BladeAgentService.CalibrateFan:input_type -> api.bladeapi.v1alpha1.CalibrateFanRequest
+	15, // 32: api.bladeapi.v1alpha1.BladeAgentService.BoostFan:input_type -> api.bladeapi.v1alpha1.BoostFanRequest
+	10, // 33: api.bladeapi.v1alpha1.BladeAgentService.SetLed:input_type -> api.bladeapi.v1alpha1.SetLedRequest
+	11, // 34: api.bladeapi.v1alpha1.BladeAgentService.ClearLed:input_type -> api.bladeapi.v1alpha1.ClearLedRequest
+	12, // 35: api.bladeapi.v1alpha1.BladeAgentService.SetLedBrightness:input_type -> api.bladeapi.v1alpha1.SetLedBrightnessRequest
+	28, // 36: api.bladeapi.v1alpha1.BladeAgentService.EmitEvent:output_type -> google.protobuf.Empty
+	28, // 37: api.bladeapi.v1alpha1.BladeAgentService.SetIdentify:output_type -> google.protobuf.Empty
+	28, // 38: api.bladeapi.v1alpha1.BladeAgentService.WaitForIdentifyConfirm:output_type -> google.protobuf.Empty
+	28, // 39: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeed:output_type -> google.protobuf.Empty
+	28, // 40: api.bladeapi.v1alpha1.BladeAgentService.SetFanSpeedAuto:output_type -> google.protobuf.Empty
+	28, // 41: api.bladeapi.v1alpha1.BladeAgentService.SetStealthMode:output_type -> google.protobuf.Empty
+	27, // 42: api.bladeapi.v1alpha1.BladeAgentService.GetStatus:output_type -> api.bladeapi.v1alpha1.StatusResponse
+	28, // 43: api.bladeapi.v1alpha1.BladeAgentService.SetFanCurve:output_type -> google.protobuf.Empty
+	21, // 44: api.bladeapi.v1alpha1.BladeAgentService.GetFanCurve:output_type -> api.bladeapi.v1alpha1.FanCurveResponse
+	28, // 45: api.bladeapi.v1alpha1.BladeAgentService.SetFanProfile:output_type -> google.protobuf.Empty
+	25, // 46: api.bladeapi.v1alpha1.BladeAgentService.CalibrateFan:output_type -> api.bladeapi.v1alpha1.CalibrateFanResponse
+	28, // 47: api.bladeapi.v1alpha1.BladeAgentService.BoostFan:output_type -> google.protobuf.Empty
+	28, // 48: api.bladeapi.v1alpha1.BladeAgentService.SetLed:output_type -> google.protobuf.Empty
+	28, // 49: api.bladeapi.v1alpha1.BladeAgentService.ClearLed:output_type -> google.protobuf.Empty
+	28, // 50: api.bladeapi.v1alpha1.BladeAgentService.SetLedBrightness:output_type -> google.protobuf.Empty
+	36, // [36:51] is the sub-list for method output_type
+	21, // [21:36] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_api_bladeapi_v1alpha1_blade_proto_init() }
@@ -724,7 +2283,7 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StealthModeRequest); i {
+			switch v := v.(*LedColor); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -736,7 +2295,7 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 			}
 		}
 		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetFanSpeedRequest); i {
+			switch v := v.(*LedKeyframe); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -748,7 +2307,7 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 			}
 		}
 		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmitEventRequest); i {
+			switch v := v.(*SetLedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -760,7 +2319,7 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 			}
 		}
 		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FanCurveStep); i {
+			switch v := v.(*ClearLedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -772,7 +2331,7 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 			}
 		}
 		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionInfo); i {
+			switch v := v.(*SetLedBrightnessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -784,6 +2343,174 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 			}
 		}
 		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StealthModeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFanSpeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoostFanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetIdentifyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmitEventRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanCurveStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFanCurveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFanProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanCurveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalibrateFanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanCalibrationPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalibrateFanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_bladeapi_v1alpha1_blade_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatusResponse); i {
 			case 0:
 				return &v.state
@@ -796,13 +2523,15 @@ func file_api_bladeapi_v1alpha1_blade_proto_init() {
 			}
 		}
 	}
+	file_api_bladeapi_v1alpha1_blade_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_api_bladeapi_v1alpha1_blade_proto_msgTypes[14].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_bladeapi_v1alpha1_blade_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   6,
+			NumEnums:      8,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
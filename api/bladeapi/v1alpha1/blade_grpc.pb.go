@@ -21,11 +21,20 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	BladeAgentService_EmitEvent_FullMethodName              = "/api.bladeapi.v1alpha1.BladeAgentService/EmitEvent"
+	BladeAgentService_SetIdentify_FullMethodName            = "/api.bladeapi.v1alpha1.BladeAgentService/SetIdentify"
 	BladeAgentService_WaitForIdentifyConfirm_FullMethodName = "/api.bladeapi.v1alpha1.BladeAgentService/WaitForIdentifyConfirm"
 	BladeAgentService_SetFanSpeed_FullMethodName            = "/api.bladeapi.v1alpha1.BladeAgentService/SetFanSpeed"
 	BladeAgentService_SetFanSpeedAuto_FullMethodName        = "/api.bladeapi.v1alpha1.BladeAgentService/SetFanSpeedAuto"
 	BladeAgentService_SetStealthMode_FullMethodName         = "/api.bladeapi.v1alpha1.BladeAgentService/SetStealthMode"
 	BladeAgentService_GetStatus_FullMethodName              = "/api.bladeapi.v1alpha1.BladeAgentService/GetStatus"
+	BladeAgentService_SetFanCurve_FullMethodName            = "/api.bladeapi.v1alpha1.BladeAgentService/SetFanCurve"
+	BladeAgentService_GetFanCurve_FullMethodName            = "/api.bladeapi.v1alpha1.BladeAgentService/GetFanCurve"
+	BladeAgentService_SetFanProfile_FullMethodName          = "/api.bladeapi.v1alpha1.BladeAgentService/SetFanProfile"
+	BladeAgentService_CalibrateFan_FullMethodName           = "/api.bladeapi.v1alpha1.BladeAgentService/CalibrateFan"
+	BladeAgentService_BoostFan_FullMethodName               = "/api.bladeapi.v1alpha1.BladeAgentService/BoostFan"
+	BladeAgentService_SetLed_FullMethodName                 = "/api.bladeapi.v1alpha1.BladeAgentService/SetLed"
+	BladeAgentService_ClearLed_FullMethodName               = "/api.bladeapi.v1alpha1.BladeAgentService/ClearLed"
+	BladeAgentService_SetLedBrightness_FullMethodName       = "/api.bladeapi.v1alpha1.BladeAgentService/SetLedBrightness"
 )
 
 // BladeAgentServiceClient is the client API for BladeAgentService service.
@@ -34,6 +43,8 @@ const (
 type BladeAgentServiceClient interface {
 	// EmitEvent emits an event to the blade
 	EmitEvent(ctx context.Context, in *EmitEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SetIdentify activates identify mode, optionally with a custom pattern and a duration after which it is cleared
+	SetIdentify(ctx context.Context, in *SetIdentifyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// WaitForIdentifyConfirm blocks until the blades button is pressed
 	WaitForIdentifyConfirm(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Sets the fan speed to a specific value.
@@ -46,6 +57,25 @@ type BladeAgentServiceClient interface {
 	SetStealthMode(ctx context.Context, in *StealthModeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Gets the current status of the blade
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Replaces the fan curve of the blade
+	SetFanCurve(ctx context.Context, in *SetFanCurveRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Gets the fan curve of the blade
+	GetFanCurve(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FanCurveResponse, error)
+	// Switches the fan curve to a named fan profile from the agent configuration
+	SetFanProfile(ctx context.Context, in *SetFanProfileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sweeps the fan through a range of duty cycles and stores the measured fan speeds.
+	// The calibration is used to keep the fan from stalling. This call blocks until the calibration is complete.
+	CalibrateFan(ctx context.Context, in *CalibrateFanRequest, opts ...grpc.CallOption) (*CalibrateFanResponse, error)
+	// Raises the fan speed to at least the given percent for a limited time, e.g. to pre-cool the blade before heavy jobs.
+	// Boosts are layered on top of the fan curve, of several overlapping boosts the highest one wins.
+	BoostFan(ctx context.Context, in *BoostFanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Shows a user defined pattern on an LED, e.g. to color-code blades by role.
+	// The pattern yields to identify and critical mode, and is hidden in stealth mode.
+	SetLed(ctx context.Context, in *SetLedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes the user defined pattern from an LED
+	ClearLed(ctx context.Context, in *ClearLedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sets the brightness of all LEDs until the next schedule boundary changing the LED brightness
+	SetLedBrightness(ctx context.Context, in *SetLedBrightnessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type bladeAgentServiceClient struct {
@@ -65,6 +95,15 @@ func (c *bladeAgentServiceClient) EmitEvent(ctx context.Context, in *EmitEventRe
 	return out, nil
 }
 
+func (c *bladeAgentServiceClient) SetIdentify(ctx context.Context, in *SetIdentifyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_SetIdentify_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bladeAgentServiceClient) WaitForIdentifyConfirm(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, BladeAgentService_WaitForIdentifyConfirm_FullMethodName, in, out, opts...)
@@ -110,12 +149,86 @@ func (c *bladeAgentServiceClient) GetStatus(ctx context.Context, in *emptypb.Emp
 	return out, nil
 }
 
+func (c *bladeAgentServiceClient) SetFanCurve(ctx context.Context, in *SetFanCurveRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_SetFanCurve_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) GetFanCurve(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FanCurveResponse, error) {
+	out := new(FanCurveResponse)
+	err := c.cc.Invoke(ctx, BladeAgentService_GetFanCurve_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) SetFanProfile(ctx context.Context, in *SetFanProfileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_SetFanProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) CalibrateFan(ctx context.Context, in *CalibrateFanRequest, opts ...grpc.CallOption) (*CalibrateFanResponse, error) {
+	out := new(CalibrateFanResponse)
+	err := c.cc.Invoke(ctx, BladeAgentService_CalibrateFan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) BoostFan(ctx context.Context, in *BoostFanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_BoostFan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) SetLed(ctx context.Context, in *SetLedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_SetLed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) ClearLed(ctx context.Context, in *ClearLedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_ClearLed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bladeAgentServiceClient) SetLedBrightness(ctx context.Context, in *SetLedBrightnessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BladeAgentService_SetLedBrightness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BladeAgentServiceServer is the server API for BladeAgentService service.
 // All implementations must embed UnimplementedBladeAgentServiceServer
 // for forward compatibility
 type BladeAgentServiceServer interface {
 	// EmitEvent emits an event to the blade
 	EmitEvent(context.Context, *EmitEventRequest) (*emptypb.Empty, error)
+	// SetIdentify activates identify mode, optionally with a custom pattern and a duration after which it is cleared
+	SetIdentify(context.Context, *SetIdentifyRequest) (*emptypb.Empty, error)
 	// WaitForIdentifyConfirm blocks until the blades button is pressed
 	WaitForIdentifyConfirm(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	// Sets the fan speed to a specific value.
@@ -128,6 +241,25 @@ type BladeAgentServiceServer interface {
 	SetStealthMode(context.Context, *StealthModeRequest) (*emptypb.Empty, error)
 	// Gets the current status of the blade
 	GetStatus(context.Context, *emptypb.Empty) (*StatusResponse, error)
+	// Replaces the fan curve of the blade
+	SetFanCurve(context.Context, *SetFanCurveRequest) (*emptypb.Empty, error)
+	// Gets the fan curve of the blade
+	GetFanCurve(context.Context, *emptypb.Empty) (*FanCurveResponse, error)
+	// Switches the fan curve to a named fan profile from the agent configuration
+	SetFanProfile(context.Context, *SetFanProfileRequest) (*emptypb.Empty, error)
+	// Sweeps the fan through a range of duty cycles and stores the measured fan speeds.
+	// The calibration is used to keep the fan from stalling. This call blocks until the calibration is complete.
+	CalibrateFan(context.Context, *CalibrateFanRequest) (*CalibrateFanResponse, error)
+	// Raises the fan speed to at least the given percent for a limited time, e.g. to pre-cool the blade before heavy jobs.
+	// Boosts are layered on top of the fan curve, of several overlapping boosts the highest one wins.
+	BoostFan(context.Context, *BoostFanRequest) (*emptypb.Empty, error)
+	// Shows a user defined pattern on an LED, e.g. to color-code blades by role.
+	// The pattern yields to identify and critical mode, and is hidden in stealth mode.
+	SetLed(context.Context, *SetLedRequest) (*emptypb.Empty, error)
+	// Removes the user defined pattern from an LED
+	ClearLed(context.Context, *ClearLedRequest) (*emptypb.Empty, error)
+	// Sets the brightness of all LEDs until the next schedule boundary changing the LED brightness
+	SetLedBrightness(context.Context, *SetLedBrightnessRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedBladeAgentServiceServer()
 }
 
@@ -138,6 +270,9 @@ type UnimplementedBladeAgentServiceServer struct {
 func (UnimplementedBladeAgentServiceServer) EmitEvent(context.Context, *EmitEventRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EmitEvent not implemented")
 }
+func (UnimplementedBladeAgentServiceServer) SetIdentify(context.Context, *SetIdentifyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIdentify not implemented")
+}
 func (UnimplementedBladeAgentServiceServer) WaitForIdentifyConfirm(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WaitForIdentifyConfirm not implemented")
 }
@@ -153,6 +288,30 @@ func (UnimplementedBladeAgentServiceServer) SetStealthMode(context.Context, *Ste
 func (UnimplementedBladeAgentServiceServer) GetStatus(context.Context, *emptypb.Empty) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedBladeAgentServiceServer) SetFanCurve(context.Context, *SetFanCurveRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFanCurve not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) GetFanCurve(context.Context, *emptypb.Empty) (*FanCurveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFanCurve not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) SetFanProfile(context.Context, *SetFanProfileRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFanProfile not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) CalibrateFan(context.Context, *CalibrateFanRequest) (*CalibrateFanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalibrateFan not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) BoostFan(context.Context, *BoostFanRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BoostFan not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) SetLed(context.Context, *SetLedRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLed not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) ClearLed(context.Context, *ClearLedRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearLed not implemented")
+}
+func (UnimplementedBladeAgentServiceServer) SetLedBrightness(context.Context, *SetLedBrightnessRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLedBrightness not implemented")
+}
 func (UnimplementedBladeAgentServiceServer) mustEmbedUnimplementedBladeAgentServiceServer() {}
 
 // UnsafeBladeAgentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -184,6 +343,24 @@ func _BladeAgentService_EmitEvent_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BladeAgentService_SetIdentify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIdentifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).SetIdentify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_SetIdentify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).SetIdentify(ctx, req.(*SetIdentifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BladeAgentService_WaitForIdentifyConfirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -274,6 +451,150 @@ func _BladeAgentService_GetStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BladeAgentService_SetFanCurve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFanCurveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).SetFanCurve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_SetFanCurve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).SetFanCurve(ctx, req.(*SetFanCurveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_GetFanCurve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).GetFanCurve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_GetFanCurve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).GetFanCurve(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_SetFanProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFanProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).SetFanProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_SetFanProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).SetFanProfile(ctx, req.(*SetFanProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_CalibrateFan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalibrateFanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).CalibrateFan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_CalibrateFan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).CalibrateFan(ctx, req.(*CalibrateFanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_BoostFan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoostFanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).BoostFan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_BoostFan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).BoostFan(ctx, req.(*BoostFanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_SetLed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).SetLed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_SetLed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).SetLed(ctx, req.(*SetLedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_ClearLed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearLedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).ClearLed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_ClearLed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).ClearLed(ctx, req.(*ClearLedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BladeAgentService_SetLedBrightness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLedBrightnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BladeAgentServiceServer).SetLedBrightness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BladeAgentService_SetLedBrightness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BladeAgentServiceServer).SetLedBrightness(ctx, req.(*SetLedBrightnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BladeAgentService_ServiceDesc is the grpc.ServiceDesc for BladeAgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,6 +606,10 @@ var BladeAgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EmitEvent",
 			Handler:    _BladeAgentService_EmitEvent_Handler,
 		},
+		{
+			MethodName: "SetIdentify",
+			Handler:    _BladeAgentService_SetIdentify_Handler,
+		},
 		{
 			MethodName: "WaitForIdentifyConfirm",
 			Handler:    _BladeAgentService_WaitForIdentifyConfirm_Handler,
@@ -305,6 +630,38 @@ var BladeAgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _BladeAgentService_GetStatus_Handler,
 		},
+		{
+			MethodName: "SetFanCurve",
+			Handler:    _BladeAgentService_SetFanCurve_Handler,
+		},
+		{
+			MethodName: "GetFanCurve",
+			Handler:    _BladeAgentService_GetFanCurve_Handler,
+		},
+		{
+			MethodName: "SetFanProfile",
+			Handler:    _BladeAgentService_SetFanProfile_Handler,
+		},
+		{
+			MethodName: "CalibrateFan",
+			Handler:    _BladeAgentService_CalibrateFan_Handler,
+		},
+		{
+			MethodName: "BoostFan",
+			Handler:    _BladeAgentService_BoostFan_Handler,
+		},
+		{
+			MethodName: "SetLed",
+			Handler:    _BladeAgentService_SetLed_Handler,
+		},
+		{
+			MethodName: "ClearLed",
+			Handler:    _BladeAgentService_ClearLed_Handler,
+		},
+		{
+			MethodName: "SetLedBrightness",
+			Handler:    _BladeAgentService_SetLedBrightness_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/bladeapi/v1alpha1/blade.proto",
@@ -130,6 +130,7 @@ func main() {
 		cancelCtx(err)
 		log.FromContext(ctx).WithError(err).Fatal("Failed to load configuration")
 	}
+	cbAgentConfig.ConfigFile = viper.ConfigFileUsed()
 
 	// setup stop signal handlers
 	sigs := make(chan os.Signal, 1)
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"os/user"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
 	"github.com/olekukonko/tablewriter"
@@ -13,14 +20,34 @@ import (
 )
 
 var (
-	percent int
-	auto    bool
+	percent          int
+	auto             bool
+	persist          bool
+	calibrationSteps []uint
+	overrideFor      time.Duration
+	overrideOwner    string
+	boostPercent     uint
+	boostFor         time.Duration
 )
 
 func init() {
 	cmdSetFan.Flags().IntVarP(&percent, "percent", "p", 40, "Fan speed in percent (Default: 40).")
 	cmdSetFan.Flags().BoolVarP(&auto, "auto", "a", false, "Set fan speed to automatic mode.")
+	cmdSetFan.Flags().DurationVar(&overrideFor, "for", 0, "Return to automatic mode after the given duration, e.g. 10m (Default: keep the fan speed until unset).")
+	cmdSetFan.Flags().StringVar(&overrideOwner, "owner", defaultOverrideOwner(), "Owner of the fan speed override, shown in the blade status.")
 
+	cmdSetFanCurve.Flags().BoolVar(&persist, "persist", false, "Persist the fan curve to the agent configuration file.")
+	cmdFanCalibrate.Flags().UintSliceVar(&calibrationSteps, "steps", nil, "Duty cycles in percent to measure (Default: steps from the agent configuration).")
+
+	cmdFanBoost.Flags().UintVarP(&boostPercent, "percent", "p", 100, "Fan speed in percent the fan is raised to at least (Default: 100).")
+	cmdFanBoost.Flags().DurationVar(&boostFor, "for", 10*time.Minute, "Duration of the boost (Default: 10m).")
+
+	cmdFan.AddCommand(cmdFanCalibrate)
+	cmdFan.AddCommand(cmdFanBoost)
+	rootCmd.AddCommand(cmdFan)
+
+	cmdSetFan.AddCommand(cmdSetFanCurve)
+	cmdSetFan.AddCommand(cmdSetFanProfile)
 	cmdSet.AddCommand(cmdSetFan)
 	cmdGet.AddCommand(cmdGetFan)
 	cmdRemove.AddCommand(cmdRmFan)
@@ -34,7 +61,7 @@ var (
 		Use:     "fan",
 		Aliases: fanAliases,
 		Short:   "Control the fan behavior of the compute-blade",
-		Example: "bladectl set fan --percent 50",
+		Example: "bladectl set fan --percent 50 --for 10m",
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			autoSet := cmd.Flags().Changed("auto")
@@ -48,6 +75,13 @@ var (
 				return fmt.Errorf("you must specify either --auto or --percent")
 			}
 
+			if overrideFor < 0 {
+				return fmt.Errorf("--for must not be negative")
+			}
+			if autoSet && cmd.Flags().Changed("for") {
+				return fmt.Errorf("--for can only be used together with --percent")
+			}
+
 			ctx := cmd.Context()
 			clients := clientsFromContext(ctx)
 
@@ -58,7 +92,9 @@ var (
 					_, err = client.SetFanSpeedAuto(ctx, &emptypb.Empty{})
 				} else {
 					_, err = client.SetFanSpeed(ctx, &bladeapiv1alpha1.SetFanSpeedRequest{
-						Percent: int64(percent),
+						Percent:         int64(percent),
+						DurationSeconds: int64(math.Ceil(overrideFor.Seconds())),
+						Owner:           overrideOwner,
 					})
 				}
 
@@ -71,6 +107,129 @@ var (
 		},
 	}
 
+	cmdSetFanCurve = &cobra.Command{
+		Use:     "curve <temperature:percent>...",
+		Short:   "Replace the fan speed curve of the compute-blade",
+		Example: "bladectl set fan curve 40:30 60:60 70:100 --persist",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := parseFanCurveSteps(args)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+
+			for _, client := range clients {
+				if _, err := client.SetFanCurve(ctx, &bladeapiv1alpha1.SetFanCurveRequest{
+					Steps:   steps,
+					Persist: persist,
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmdSetFanProfile = &cobra.Command{
+		Use:     "profile <name>",
+		Short:   "Switch the fan speed curve of the compute-blade to a configured fan profile",
+		Example: "bladectl set fan profile quiet",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+
+			for _, client := range clients {
+				if _, err := client.SetFanProfile(ctx, &bladeapiv1alpha1.SetFanProfileRequest{
+					Name: args[0],
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmdFan = &cobra.Command{
+		Use:     "fan",
+		Aliases: fanAliases,
+		Short:   "Maintain the fan of the compute-blade",
+	}
+
+	cmdFanCalibrate = &cobra.Command{
+		Use:     "calibrate",
+		Short:   "Measure the fan speed at a range of duty cycles, so the agent never stalls the fan",
+		Long:    "Sweeps the fan through a range of duty cycles and records the fan speed at each step. The agent stores the result and keeps the fan above the duty cycle it stalls at. The calibration takes a few minutes.",
+		Example: "bladectl fan calibrate --steps 0,10,20,30,40,50,60,70,80,90,100",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+
+			steps := make([]uint32, len(calibrationSteps))
+			for idx, step := range calibrationSteps {
+				if step > 100 {
+					return fmt.Errorf("--steps must be between 0 and 100, got %d", step)
+				}
+				steps[idx] = uint32(step)
+			}
+
+			// Calibrate all blades at once, a calibration takes minutes
+			calibrations := make([]*bladeapiv1alpha1.CalibrateFanResponse, len(clients))
+			errs := make([]error, len(clients))
+			var wg sync.WaitGroup
+			for idx, client := range clients {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					calibrations[idx], errs[idx] = client.CalibrateFan(ctx, &bladeapiv1alpha1.CalibrateFanRequest{
+						Steps: steps,
+					})
+				}()
+			}
+			wg.Wait()
+
+			if err := errors.Join(errs...); err != nil {
+				return err
+			}
+
+			printFanCalibrationTable(calibrations)
+			return nil
+		},
+	}
+
+	cmdFanBoost = &cobra.Command{
+		Use:     "boost",
+		Short:   "Temporarily raise the fan speed of the compute-blade, e.g. to pre-cool it before heavy jobs",
+		Long:    "Raises the fan speed to at least the given percent for a limited time. The boost is layered on top of the fan curve, of several overlapping boosts the highest one wins.",
+		Example: "bladectl fan boost --percent 100 --for 15m",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if boostPercent > 100 {
+				return fmt.Errorf("--percent must be between 0 and 100, got %d", boostPercent)
+			}
+
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+
+			for _, client := range clients {
+				if _, err := client.BoostFan(ctx, &bladeapiv1alpha1.BoostFanRequest{
+					Percent:         uint32(boostPercent),
+					DurationSeconds: int64(math.Ceil(boostFor.Seconds())),
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
 	cmdRmFan = &cobra.Command{
 		Use:     "fan",
 		Aliases: fanAliases,
@@ -117,6 +276,12 @@ var (
 				}
 
 				fmt.Println(rpmStyle(rpm).Render(fmt.Sprint(rowPrefix + rpmLabel(rpm) + " (" + percentLabel(percent) + ")")))
+				if !bladeStatus.FanSpeedAutomatic {
+					fmt.Println(speedOverrideStyle(false).Render(rowPrefix + "Override: " + fanSpeedOverrideLabel(bladeStatus)))
+				}
+				if bladeStatus.FanBoostPercent > 0 {
+					fmt.Println(speedOverrideStyle(false).Render(rowPrefix + "Boost: " + fanBoostLabel(bladeStatus)))
+				}
 			}
 
 			return nil
@@ -136,13 +301,13 @@ var (
 			bladeFanCurves := make([][]*bladeapiv1alpha1.FanCurveStep, len(clients))
 			criticalTemps := make([]int64, len(clients))
 			for idx, client := range clients {
-				bladeStatus, err := client.GetStatus(ctx, &emptypb.Empty{})
+				fanCurve, err := client.GetFanCurve(ctx, &emptypb.Empty{})
 				if err != nil {
 					return err
 				}
 
-				bladeFanCurves[idx] = bladeStatus.FanCurveSteps
-				criticalTemps[idx] = bladeStatus.CriticalTemperatureThreshold
+				bladeFanCurves[idx] = fanCurve.Steps
+				criticalTemps[idx] = fanCurve.CriticalTemperatureThreshold
 			}
 
 			printFanCurveTable(bladeFanCurves, criticalTemps)
@@ -151,6 +316,46 @@ var (
 	}
 )
 
+// defaultOverrideOwner identifies the user setting a fan speed override as user@host
+func defaultOverrideOwner() string {
+	owner := "unknown"
+	if u, err := user.Current(); err == nil {
+		owner = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		owner += "@" + host
+	}
+	return owner
+}
+
+// parseFanCurveSteps parses fan curve steps given as temperature:percent pairs
+func parseFanCurveSteps(args []string) ([]*bladeapiv1alpha1.FanCurveStep, error) {
+	steps := make([]*bladeapiv1alpha1.FanCurveStep, len(args))
+	for idx, arg := range args {
+		tempStr, percentStr, ok := strings.Cut(arg, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid fan curve step %q, expected <temperature:percent>", arg)
+		}
+
+		temp, err := strconv.ParseInt(tempStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid temperature in fan curve step %q: %w", arg, err)
+		}
+
+		stepPercent, err := strconv.ParseUint(percentStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid percent in fan curve step %q: %w", arg, err)
+		}
+
+		steps[idx] = &bladeapiv1alpha1.FanCurveStep{
+			Temperature: temp,
+			Percent:     uint32(stepPercent),
+		}
+	}
+
+	return steps, nil
+}
+
 func printFanCurveTable(bladeValues [][]*bladeapiv1alpha1.FanCurveStep, criticalTemps []int64) {
 	bladeCount := len(bladeValues)
 
@@ -207,3 +412,59 @@ func printFanCurveTable(bladeValues [][]*bladeapiv1alpha1.FanCurveStep, critical
 
 	_ = tbl.Render()
 }
+
+func printFanCalibrationTable(calibrations []*bladeapiv1alpha1.CalibrateFanResponse) {
+	// Collect all measured duty cycles, the blades may have been calibrated with different steps
+	allPercentsSet := make(map[uint32]struct{})
+	for _, calibration := range calibrations {
+		for _, point := range calibration.Points {
+			allPercentsSet[point.Percent] = struct{}{}
+		}
+	}
+
+	var allPercents []uint32
+	for p := range allPercentsSet {
+		allPercents = append(allPercents, p)
+	}
+
+	sort.Slice(allPercents, func(i, j int) bool {
+		return allPercents[i] < allPercents[j]
+	})
+
+	// Header: Blade | Spin-Up | Min Running | Percent1 | Percent2 | ...
+	header := []string{"Blade", "Spin-Up", "Min Running"}
+	for _, p := range allPercents {
+		header = append(header, percentLabel(p))
+	}
+
+	// Table writer setup
+	tbl := tablewriter.NewTable(os.Stdout,
+		tablewriter.WithHeader(header),
+		tablewriter.WithHeaderAlignment(tw.AlignLeft),
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+	)
+
+	// Rows: one per blade
+	for bladeIdx, calibration := range calibrations {
+		rpms := make(map[uint32]int64)
+		for _, point := range calibration.Points {
+			rpms[point.Percent] = point.Rpm
+		}
+
+		row := []string{
+			bladeNames[bladeIdx],
+			percentLabel(calibration.SpinUpPercent),
+			percentLabel(calibration.MinRunningPercent),
+		}
+		for _, p := range allPercents {
+			if rpm, ok := rpms[p]; ok {
+				row = append(row, rpmStyle(rpm).Render(rpmLabel(rpm)))
+			} else {
+				row = append(row, "")
+			}
+		}
+		_ = tbl.Append(row)
+	}
+
+	_ = tbl.Render()
+}
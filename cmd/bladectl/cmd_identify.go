@@ -3,6 +3,8 @@ package main
 import (
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
 	"github.com/sierrasoftworks/humane-errors-go"
@@ -11,13 +13,21 @@ import (
 )
 
 var (
-	confirm bool
-	wait    bool
+	confirm           bool
+	wait              bool
+	identifyFor       time.Duration
+	identifyPattern   string
+	identifyColor     string
+	identifyRequester string
 )
 
 func init() {
 	cmdSetIdentify.Flags().BoolVarP(&confirm, "confirm", "c", false, "confirm the identify state")
 	cmdSetIdentify.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for the identify state to be confirmed (e.g. by a physical button press)")
+	cmdSetIdentify.Flags().DurationVar(&identifyFor, "for", 0, "Clear the identify state automatically after the given duration, e.g. 30m (Default: keep it until confirmed).")
+	cmdSetIdentify.Flags().StringVar(&identifyPattern, "pattern", "", "Pattern to show: static, slow-blink, burst or breathing (Default: burst).")
+	cmdSetIdentify.Flags().StringVar(&identifyColor, "color", "", "Color of the pattern as #rrggbb or r,g,b (Default: the configured identify LED color).")
+	cmdSetIdentify.Flags().StringVar(&identifyRequester, "requester", defaultOverrideOwner(), "Requester of the identify state, shown in the blade status.")
 	cmdSet.AddCommand(cmdSetIdentify)
 	cmdRemove.AddCommand(cmdRmIdentify)
 	cmdGet.AddCommand(cmdGetIdentify)
@@ -25,29 +35,32 @@ func init() {
 
 var (
 	cmdSetIdentify = &cobra.Command{
-		Use:     "identify",
-		Example: "bladectl set identify --wait",
-		Short:   "interact with the compute-blade identity LED",
+		Use: "identify",
+		Example: `bladectl set identify --wait
+bladectl set identify --for 30m --pattern breathing --color '#0000ff'`,
+		Short: "interact with the compute-blade identity LED",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if len(bladeNames) > 1 && wait {
 				return fmt.Errorf("cannot enable identify on multiple compute-blades at the same with the --wait flag")
 			}
 
+			req, err := identifyRequestFromFlags()
+			if err != nil {
+				return err
+			}
+
 			ctx := cmd.Context()
 			clients := clientsFromContext(ctx)
 
 			for _, client := range clients {
-				// Check if we should wait for the identify state to be confirmed
-				event := bladeapiv1alpha1.Event_IDENTIFY
 				if confirm {
-					event = bladeapiv1alpha1.Event_IDENTIFY_CONFIRM
+					_, err = client.EmitEvent(ctx, &bladeapiv1alpha1.EmitEventRequest{Event: bladeapiv1alpha1.Event_IDENTIFY_CONFIRM})
+				} else {
+					_, err = client.SetIdentify(ctx, req)
 				}
-
-				// Emit the event to the compute-blade-agent
-				_, err := client.EmitEvent(ctx, &bladeapiv1alpha1.EmitEventRequest{Event: event})
 				if err != nil {
 					return errors.New(humane.Wrap(err,
-						"failed to emit event",
+						"failed to set identify state",
 						"ensure the compute-blade agent is running and responsive to requests",
 						"check the compute-blade agent logs for more information using 'journalctl -u compute-blade-agent.service'",
 					).Display())
@@ -115,10 +128,40 @@ var (
 					rowPrefix = ""
 				}
 
-				fmt.Println(activeStyle(bladeStatus.IdentifyActive).Render(rowPrefix, activeLabel(bladeStatus.IdentifyActive)))
+				fmt.Println(activeStyle(bladeStatus.IdentifyActive).Render(rowPrefix, identifyLabel(bladeStatus)))
 			}
 
 			return nil
 		},
 	}
 )
+
+// identifyRequestFromFlags returns the identify request described by the flags of the set identify command
+func identifyRequestFromFlags() (*bladeapiv1alpha1.SetIdentifyRequest, error) {
+	if identifyFor < 0 {
+		return nil, fmt.Errorf("--for must not be negative")
+	}
+
+	req := &bladeapiv1alpha1.SetIdentifyRequest{
+		DurationSeconds: int64(math.Ceil(identifyFor.Seconds())),
+		Requester:       identifyRequester,
+	}
+
+	if identifyPattern != "" {
+		pattern, ok := ledPatterns[identifyPattern]
+		if !ok || pattern == bladeapiv1alpha1.LedPattern_LED_PATTERN_KEYFRAMES {
+			return nil, fmt.Errorf("unknown pattern %q, expected one of static, slow-blink, burst or breathing", identifyPattern)
+		}
+		req.Pattern = &pattern
+	}
+
+	if identifyColor != "" {
+		color, err := parseLedColor(identifyColor)
+		if err != nil {
+			return nil, err
+		}
+		req.Color = color
+	}
+
+	return req, nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ledPattern   string
+	ledColor     string
+	ledBaseColor string
+	ledKeyframes []string
+	ledFor       time.Duration
+)
+
+func init() {
+	cmdSetLed.Flags().StringVar(&ledPattern, "pattern", "static", "Pattern to show: static, slow-blink, burst, breathing or keyframes.")
+	cmdSetLed.Flags().StringVarP(&ledColor, "color", "c", "", "Color of the pattern as #rrggbb or r,g,b.")
+	cmdSetLed.Flags().StringVar(&ledBaseColor, "base-color", "", "Color shown in between blinks and breaths as #rrggbb or r,g,b (Default: off).")
+	cmdSetLed.Flags().StringArrayVar(&ledKeyframes, "keyframe", nil, "Keyframe as <color>/<duration>[/step|linear|ease] for --pattern keyframes, may be repeated.")
+	cmdSetLed.Flags().DurationVar(&ledFor, "for", 0, "Return to the previous pattern after the given duration, e.g. 1h (Default: keep the pattern until unset).")
+
+	cmdSetLed.AddCommand(cmdSetLedBrightness)
+	cmdSet.AddCommand(cmdSetLed)
+	cmdRemove.AddCommand(cmdRmLed)
+}
+
+var (
+	ledPatterns = map[string]bladeapiv1alpha1.LedPattern{
+		"static":     bladeapiv1alpha1.LedPattern_LED_PATTERN_STATIC,
+		"slow-blink": bladeapiv1alpha1.LedPattern_LED_PATTERN_SLOW_BLINK,
+		"burst":      bladeapiv1alpha1.LedPattern_LED_PATTERN_BURST,
+		"breathing":  bladeapiv1alpha1.LedPattern_LED_PATTERN_BREATHING,
+		"keyframes":  bladeapiv1alpha1.LedPattern_LED_PATTERN_KEYFRAMES,
+	}
+
+	ledInterpolations = map[string]bladeapiv1alpha1.LedInterpolation{
+		"step":   bladeapiv1alpha1.LedInterpolation_LED_INTERPOLATION_STEP,
+		"linear": bladeapiv1alpha1.LedInterpolation_LED_INTERPOLATION_LINEAR,
+		"ease":   bladeapiv1alpha1.LedInterpolation_LED_INTERPOLATION_EASE,
+	}
+
+	cmdSetLed = &cobra.Command{
+		Use:   "led <top|edge>",
+		Short: "Show a custom pattern on an LED of the compute-blade",
+		Long:  "Shows a custom pattern on the top or edge LED. Identify and critical mode take precedence, and stealth mode hides the pattern.",
+		Example: `bladectl set led edge --color '#0000ff'
+bladectl set led top --pattern breathing --color 255,128,0 --for 1h
+bladectl set led edge --pattern keyframes --keyframe '#ff0000/1s/ease' --keyframe '#0000ff/1s/ease'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			idx, err := parseLedIndex(args[0])
+			if err != nil {
+				return err
+			}
+
+			pattern, ok := ledPatterns[ledPattern]
+			if !ok {
+				return fmt.Errorf("unknown pattern %q, expected one of static, slow-blink, burst, breathing or keyframes", ledPattern)
+			}
+
+			req := &bladeapiv1alpha1.SetLedRequest{
+				Led:        idx,
+				Pattern:    pattern,
+				TtlSeconds: int64(math.Ceil(ledFor.Seconds())),
+			}
+
+			if pattern == bladeapiv1alpha1.LedPattern_LED_PATTERN_KEYFRAMES {
+				if len(ledKeyframes) == 0 {
+					return fmt.Errorf("--pattern keyframes requires at least one --keyframe")
+				}
+				for _, arg := range ledKeyframes {
+					keyframe, err := parseLedKeyframe(arg)
+					if err != nil {
+						return err
+					}
+					req.Keyframes = append(req.Keyframes, keyframe)
+				}
+			} else {
+				if len(ledKeyframes) > 0 {
+					return fmt.Errorf("--keyframe can only be used together with --pattern keyframes")
+				}
+				if ledColor == "" {
+					return fmt.Errorf("you must specify --color")
+				}
+				if req.Color, err = parseLedColor(ledColor); err != nil {
+					return err
+				}
+				if ledBaseColor != "" {
+					if req.BaseColor, err = parseLedColor(ledBaseColor); err != nil {
+						return err
+					}
+				}
+			}
+
+			if ledFor < 0 {
+				return fmt.Errorf("--for must not be negative")
+			}
+
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+			for _, client := range clients {
+				if _, err := client.SetLed(ctx, req); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmdSetLedBrightness = &cobra.Command{
+		Use:     "brightness <percent>",
+		Short:   "Set the brightness of all LEDs of the compute-blade",
+		Long:    "Scales all LED colors with the given brightness. The LED brightness schedule takes over again at its next boundary.",
+		Example: "bladectl set led brightness 30",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			brightness, err := strconv.ParseUint(strings.TrimSuffix(args[0], "%"), 10, 8)
+			if err != nil || brightness > 100 {
+				return fmt.Errorf("invalid brightness %q, expected a percentage between 0 and 100", args[0])
+			}
+
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+			for _, client := range clients {
+				if _, err := client.SetLedBrightness(ctx, &bladeapiv1alpha1.SetLedBrightnessRequest{
+					Percent: uint32(brightness),
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmdRmLed = &cobra.Command{
+		Use:     "led <top|edge>",
+		Short:   "Remove the custom pattern from an LED of the compute-blade",
+		Example: "bladectl remove led edge",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			idx, err := parseLedIndex(args[0])
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			clients := clientsFromContext(ctx)
+			for _, client := range clients {
+				if _, err := client.ClearLed(ctx, &bladeapiv1alpha1.ClearLedRequest{Led: idx}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+)
+
+func parseLedIndex(arg string) (bladeapiv1alpha1.LedIndex, error) {
+	switch strings.ToLower(arg) {
+	case "top":
+		return bladeapiv1alpha1.LedIndex_LED_TOP, nil
+	case "edge":
+		return bladeapiv1alpha1.LedIndex_LED_EDGE, nil
+	default:
+		return 0, fmt.Errorf("unknown LED %q, expected top or edge", arg)
+	}
+}
+
+// parseLedColor parses a color given as #rrggbb or r,g,b
+func parseLedColor(arg string) (*bladeapiv1alpha1.LedColor, error) {
+	if hex, ok := strings.CutPrefix(arg, "#"); ok {
+		value, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return nil, fmt.Errorf("invalid color %q, expected #rrggbb or r,g,b", arg)
+		}
+		return &bladeapiv1alpha1.LedColor{
+			Red:   uint32(value >> 16 & 0xff),
+			Green: uint32(value >> 8 & 0xff),
+			Blue:  uint32(value & 0xff),
+		}, nil
+	}
+
+	parts := strings.Split(arg, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid color %q, expected #rrggbb or r,g,b", arg)
+	}
+	var components [3]uint32
+	for idx, part := range parts {
+		value, err := strconv.ParseUint(strings.TrimSpace(part), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q, components must be between 0 and 255", arg)
+		}
+		components[idx] = uint32(value)
+	}
+
+	return &bladeapiv1alpha1.LedColor{Red: components[0], Green: components[1], Blue: components[2]}, nil
+}
+
+// parseLedKeyframe parses a keyframe given as <color>/<duration>[/interpolation]
+func parseLedKeyframe(arg string) (*bladeapiv1alpha1.LedKeyframe, error) {
+	parts := strings.Split(arg, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid keyframe %q, expected <color>/<duration>[/step|linear|ease]", arg)
+	}
+
+	color, err := parseLedColor(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration in keyframe %q: %w", arg, err)
+	}
+
+	interpolation := bladeapiv1alpha1.LedInterpolation_LED_INTERPOLATION_STEP
+	if len(parts) == 3 {
+		var ok bool
+		if interpolation, ok = ledInterpolations[parts[2]]; !ok {
+			return nil, fmt.Errorf("unknown interpolation in keyframe %q, expected step, linear or ease", arg)
+		}
+	}
+
+	return &bladeapiv1alpha1.LedKeyframe{
+		Color:         color,
+		DurationMs:    duration.Milliseconds(),
+		Interpolation: interpolation,
+	}, nil
+}
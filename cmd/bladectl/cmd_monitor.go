@@ -135,7 +135,7 @@ func renderCharts(status *bladeapiv1alpha1.StatusResponse, fanPlot, tempPlot *wi
 			labelBox.Text = fmt.Sprintf(
 				"%s | Fan Override: %s",
 				labelBox.Text,
-				fanSpeedOverrideLabel(status.FanSpeedAutomatic, status.FanPercent),
+				fanSpeedOverrideLabel(status),
 			)
 		}
 
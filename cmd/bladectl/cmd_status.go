@@ -43,11 +43,18 @@ func printStatusTable(bladeStatus []*bladeapiv1alpha1.StatusResponse) {
 		"Blade",
 		"Temperature",
 		"Fan Speed Override",
+		"Fan Boost",
 		"Fan Speed",
+		"Fan Health",
+		"Fan Profile",
+		"Next Schedule",
 		"Stealth Mode",
+		"LED Brightness",
 		"Identify",
 		"Critical Mode",
+		"Faults",
 		"Power Status",
+		"Compute Module",
 	}
 
 	// Table writer setup
@@ -62,12 +69,19 @@ func printStatusTable(bladeStatus []*bladeapiv1alpha1.StatusResponse) {
 		row := []string{
 			bladeNames[bladeIdx],
 			tempStyle(status.Temperature, status.CriticalTemperatureThreshold).Render(tempLabel(status.Temperature)),
-			speedOverrideStyle(status.FanSpeedAutomatic).Render(fanSpeedOverrideLabel(status.FanSpeedAutomatic, status.FanPercent)),
+			speedOverrideStyle(status.FanSpeedAutomatic).Render(fanSpeedOverrideLabel(status)),
+			speedOverrideStyle(status.FanBoostPercent == 0).Render(fanBoostLabel(status)),
 			rpmStyle(status.FanRpm).Render(rpmLabel(status.FanRpm) + " (" + percentLabel(status.FanPercent) + ")"),
+			fanFailureStyle(status.FanFailure).Render(fanFailureLabel(status.FanFailure)),
+			okStyle().Render(fanProfileLabel(status.FanProfile)),
+			okStyle().Render(scheduleTransitionLabel(status.NextScheduleTransition)),
 			activeStyle(status.StealthMode).Render(activeLabel(status.StealthMode)),
-			activeStyle(status.IdentifyActive).Render(activeLabel(status.IdentifyActive)),
+			okStyle().Render(percentLabel(status.LedBrightnessPercent)),
+			activeStyle(status.IdentifyActive).Render(identifyLabel(status)),
 			activeStyle(status.CriticalActive).Render(activeLabel(status.CriticalActive)),
+			faultsStyle(status.Faults).Render(faultsLabel(status.Faults)),
 			okStyle().Render(hal.PowerStatus(status.PowerStatus).String()),
+			okStyle().Render(computeModuleLabel(status.ComputeModule)),
 		}
 
 		_ = tbl.Append(row)
@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
 )
 
 const (
@@ -12,11 +15,123 @@ const (
 	ColorOk       = lipgloss.Color("#04B575")
 )
 
-func fanSpeedOverrideLabel(automatic bool, percent uint32) string {
-	if automatic {
+func fanSpeedOverrideLabel(status *bladeapiv1alpha1.StatusResponse) string {
+	if status.FanSpeedAutomatic {
 		return "Not set"
 	}
-	return fmt.Sprintf("%d%%", percent)
+
+	label := fmt.Sprintf("%d%%", status.FanPercent)
+	if status.FanOverrideOwner != "" {
+		label += " by " + status.FanOverrideOwner
+	}
+	if status.FanOverrideRemainingSeconds > 0 {
+		label += fmt.Sprintf(" (%s left)", time.Duration(status.FanOverrideRemainingSeconds)*time.Second)
+	}
+	return label
+}
+
+func fanBoostLabel(status *bladeapiv1alpha1.StatusResponse) string {
+	if status.FanBoostPercent == 0 {
+		return "None"
+	}
+	return fmt.Sprintf("%d%% (%s left)", status.FanBoostPercent, time.Duration(status.FanBoostRemainingSeconds)*time.Second)
+}
+
+func identifyLabel(status *bladeapiv1alpha1.StatusResponse) string {
+	if !status.IdentifyActive {
+		return activeLabel(false)
+	}
+
+	label := activeLabel(true)
+	if status.IdentifyRequester != "" {
+		label += " by " + status.IdentifyRequester
+	}
+	if status.IdentifySince > 0 {
+		label += " since " + time.Unix(status.IdentifySince, 0).Format("Mon 15:04")
+	}
+	if status.IdentifyRemainingSeconds > 0 {
+		label += fmt.Sprintf(" (%s left)", time.Duration(status.IdentifyRemainingSeconds)*time.Second)
+	}
+	return label
+}
+
+func fanProfileLabel(profile string) string {
+	if profile == "" {
+		return "Custom"
+	}
+	return profile
+}
+
+func computeModuleLabel(model string) string {
+	// Agents before the HAL driver registry don't report the compute module
+	if model == "" {
+		return "Unknown"
+	}
+	return model
+}
+
+func scheduleTransitionLabel(transition *bladeapiv1alpha1.ScheduleTransition) string {
+	if transition == nil {
+		return "None"
+	}
+
+	var changes []string
+	if transition.FanProfile != "" {
+		changes = append(changes, "profile "+transition.FanProfile)
+	}
+	if transition.StealthMode != nil && transition.GetStealthMode() {
+		changes = append(changes, "stealth on")
+	} else if transition.StealthMode != nil {
+		changes = append(changes, "stealth off")
+	}
+	if transition.LedBrightness != nil {
+		changes = append(changes, fmt.Sprintf("LED brightness %d%%", transition.GetLedBrightness()))
+	}
+
+	return time.Unix(transition.Time, 0).Format("Mon 15:04") + ": " + strings.Join(changes, ", ")
+}
+
+func fanFailureLabel(failure bladeapiv1alpha1.FanFailure) string {
+	switch failure {
+	case bladeapiv1alpha1.FanFailure_FAN_FAILURE_NONE:
+		return "OK"
+	case bladeapiv1alpha1.FanFailure_FAN_FAILURE_STALL:
+		return "Stalled"
+	case bladeapiv1alpha1.FanFailure_FAN_FAILURE_TACH_MISSING:
+		return "No tach signal"
+	case bladeapiv1alpha1.FanFailure_FAN_FAILURE_RPM_OUT_OF_RANGE:
+		return "RPM out of range"
+	default:
+		return "Unknown"
+	}
+}
+
+func faultLabel(fault bladeapiv1alpha1.Fault) string {
+	switch fault {
+	case bladeapiv1alpha1.Fault_FAULT_TEMPERATURE_SENSOR:
+		return "Temperature sensor"
+	case bladeapiv1alpha1.Fault_FAULT_FAN_CONTROL:
+		return "Fan control"
+	case bladeapiv1alpha1.Fault_FAULT_SMART_FAN_UNIT_MISSING:
+		return "Smart fan unit missing"
+	case bladeapiv1alpha1.Fault_FAULT_CERTIFICATE:
+		return "Certificates"
+	default:
+		return "Unknown"
+	}
+}
+
+// faultsLabel lists the faults with their blink codes, e.g. "Fan control (2x)"
+func faultsLabel(faults []bladeapiv1alpha1.Fault) string {
+	if len(faults) == 0 {
+		return "OK"
+	}
+
+	labels := make([]string, len(faults))
+	for idx, fault := range faults {
+		labels[idx] = fmt.Sprintf("%s (%dx)", faultLabel(fault), fault)
+	}
+	return strings.Join(labels, ", ")
 }
 
 func tempLabel(temp int64) string {
@@ -78,6 +193,22 @@ func rpmStyle(rpm int64) lipgloss.Style {
 	return lipgloss.NewStyle().Foreground(color)
 }
 
+func fanFailureStyle(failure bladeapiv1alpha1.FanFailure) lipgloss.Style {
+	if failure != bladeapiv1alpha1.FanFailure_FAN_FAILURE_NONE {
+		return lipgloss.NewStyle().Foreground(ColorCritical)
+	}
+
+	return lipgloss.NewStyle().Foreground(ColorOk)
+}
+
+func faultsStyle(faults []bladeapiv1alpha1.Fault) lipgloss.Style {
+	if len(faults) > 0 {
+		return lipgloss.NewStyle().Foreground(ColorCritical)
+	}
+
+	return lipgloss.NewStyle().Foreground(ColorOk)
+}
+
 func okStyle() lipgloss.Style {
 	return lipgloss.NewStyle().Foreground(ColorOk)
 }
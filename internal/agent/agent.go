@@ -2,9 +2,14 @@ package internal_agent
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
@@ -15,6 +20,8 @@ import (
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/schedule"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sierrasoftworks/humane-errors-go"
@@ -36,45 +43,105 @@ var (
 		Name:      "events_dropped_count",
 		Help:      "ComputeBlade agent internal event handler statistics (dropped events)",
 	}, []string{"type"})
+
+	// fanTargetRawPercent is a prometheus gauge exposing the fan speed as requested by the fan controller
+	fanTargetRawPercent = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "computeblade_agent",
+		Name:      "fan_target_raw_percent",
+		Help:      "Fan speed in percent as requested by the fan controller, before smoothing",
+	})
+
+	// fanTargetSmoothedPercent is a prometheus gauge exposing the fan speed after hysteresis and ramp rate limiting
+	fanTargetSmoothedPercent = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "computeblade_agent",
+		Name:      "fan_target_smoothed_percent",
+		Help:      "Fan speed in percent after hysteresis and ramp rate limiting",
+	})
+
+	// temperatureSlope is a prometheus gauge exposing the SoC temperature slope the fan feed-forward is derived from
+	temperatureSlope = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "computeblade_agent",
+		Name:      "temperature_slope",
+		Help:      "SoC temperature slope in °C per minute as seen by the fan feed-forward",
+	})
 )
 
 // computeBladeAgent manages the operation and coordination of hardware components and services for a compute blade agent.
 type computeBladeAgent struct {
 	bladeapiv1alpha1.UnimplementedBladeAgentServiceServer
-	config        agent.ComputeBladeAgentConfig
-	blade         hal.ComputeBladeHal
-	state         agent.ComputebladeState
-	edgeLedEngine ledengine.LedEngine
-	topLedEngine  ledengine.LedEngine
+	config agent.ComputeBladeAgentConfig
+	blade  hal.ComputeBladeHal
+	state  agent.ComputebladeState
+	// ledCorrection scales all LED colors with the LED brightness
+	ledCorrection *hal.LedCorrection
+	// edgeLed and topLed show the animation of the highest active LED layer
+	edgeLed       *ledengine.Compositor
+	topLed        *ledengine.Compositor
 	fanController fancontroller.FanController
-	eventChan     chan events.Event
-	server        *grpc.Server
-	agentInfo     agent.ComputeBladeAgentInfo
+	// fanProfiles switches the fan curve of fanController between the configured fan profiles
+	fanProfiles *fancontroller.Profiles
+	// fanSmoother applies hysteresis and ramp rate limits to the fan control path
+	fanSmoother *fancontroller.Smoother
+	// fanInputs are additional temperature inputs combined with the SoC temperature
+	fanInputs []*fancontroller.Input
+	// fanFeedForward raises the fan speed while the SoC temperature is rising
+	fanFeedForward *fancontroller.FeedForward
+	// fanZeroRPM stops the fan below the fan-off temperature
+	fanZeroRPM *fancontroller.ZeroRPM
+	// fanBoosts temporarily raise the fan speed on top of the fan controller
+	fanBoosts *fancontroller.Boosts
+	// fanSpeed is the fan speed (in percent) last applied by the fan controller
+	fanSpeed atomic.Uint32
+	// fanCalibrationResult keeps the fan from stalling, nil if the fan has not been calibrated
+	fanCalibrationResult atomic.Pointer[fancontroller.Calibration]
+	// fanCalibration pauses the fan controller while a fan calibration is running
+	fanCalibration fanCalibrationRun
+	// idleLedShownColor is the temperature gradient color last shown on the idle edge LED, if idleLedShown.
+	// Only accessed by the fan controller.
+	idleLedShown      bool
+	idleLedShownColor led.Color
+	// identify holds the request applied by the next identify event
+	identify identifyMode
+	// identifyTracker describes the active identify mode and expires it
+	identifyTracker *agent.IdentifyTracker
+	// stealthMode is the requested stealth mode, which is restored once critical mode is cleared
+	stealthMode atomic.Bool
+	// criticalOverride indicates whether critical mode overrides the fan speed. preCriticalOverride is the fan speed
+	// override it replaced, which is restored once critical mode is cleared. Both are guarded by criticalOverrideMu.
+	criticalOverrideMu  sync.Mutex
+	criticalOverride    bool
+	preCriticalOverride *fancontroller.FanOverrideOpts
+	// schedule switches the fan profile and stealth mode at the configured times
+	schedule  *schedule.Schedule
+	eventChan chan events.Event
+	// criticalMonitor raises and clears critical mode based on the SoC temperature
+	criticalMonitor *agent.CriticalTemperatureMonitor
+	// fanHealthMonitor detects failed fans by comparing the commanded with the measured fan speed
+	fanHealthMonitor *agent.FanHealthMonitor
+	// faults are the active fault conditions, shown as blink codes on the top LED.
+	// faultsMu keeps the blink codes in line with concurrent fault changes.
+	faults    agent.FaultSet
+	faultsMu  sync.Mutex
+	server    *grpc.Server
+	agentInfo agent.ComputeBladeAgentInfo
+	// clock sets and reports the expiry of fan speed overrides and boosts
+	clock util.Clock
+	// serverTLS is the TLS configuration of the authenticated gRPC server, nil until the certificates are set up
+	serverTLS atomic.Pointer[tls.Config]
 }
 
 // NewComputeBladeAgent creates and initializes a new ComputeBladeAgent, including gRPC server setup and hardware interfaces.
 func NewComputeBladeAgent(ctx context.Context, config agent.ComputeBladeAgentConfig, agentInfo agent.ComputeBladeAgentInfo) (agent.ComputeBladeAgent, error) {
-	blade, err := hal.NewCm4Hal(ctx, config.ComputeBladeHalOpts)
+	blade, err := hal.New(ctx, config.ComputeBladeHalOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	fanController, err := fancontroller.NewLinearFanController(config.FanControllerConfig)
+	a, err := newComputeBladeAgent(ctx, config, agentInfo, blade, util.RealClock{})
 	if err != nil {
 		return nil, err
 	}
 
-	a := &computeBladeAgent{
-		config:        config,
-		blade:         blade,
-		edgeLedEngine: ledengine.New(blade, hal.LedEdge),
-		topLedEngine:  ledengine.New(blade, hal.LedTop),
-		fanController: fanController,
-		state:         agent.NewComputeBladeState(),
-		eventChan:     make(chan events.Event, 10),
-		agentInfo:     agentInfo,
-	}
-
 	if err := a.setupGrpcServer(ctx); err != nil {
 		return nil, err
 	}
@@ -83,6 +150,150 @@ func NewComputeBladeAgent(ctx context.Context, config agent.ComputeBladeAgentCon
 	return a, nil
 }
 
+// newComputeBladeAgent creates the agent controlling the blade, without the gRPC server.
+// The clock sets and expires fan speed overrides and boosts, and times the critical temperature and fan health.
+func newComputeBladeAgent(ctx context.Context, config agent.ComputeBladeAgentConfig, agentInfo agent.ComputeBladeAgentInfo, blade hal.ComputeBladeHal, clock util.Clock) (*computeBladeAgent, error) {
+	// All LED colors are corrected before they are written to the LEDs
+	ledCorrection, err := hal.NewLedCorrection(blade, config.ComputeBladeHalOpts)
+	if err != nil {
+		return nil, err
+	}
+	blade = ledCorrection
+
+	fanController, err := fancontroller.New(config.FanControllerConfig, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the linear fan controller has a fan curve that profiles can replace
+	if config.FanControllerConfig.Type != "" && config.FanControllerConfig.Type != fancontroller.ControllerTypeLinear {
+		if len(config.FanProfiles) > 0 || config.FanProfile != "" {
+			return nil, humane.New(fmt.Sprintf("fan profiles are not supported by the %s fan controller", config.FanControllerConfig.Type),
+				fmt.Sprintf("Remove fan_profiles and fan_profile, or set fan_controller.type to %s", fancontroller.ControllerTypeLinear),
+			)
+		}
+		for _, entry := range config.Schedule {
+			if entry.FanProfile != "" {
+				return nil, humane.New(fmt.Sprintf("schedule entry at %s switches the fan profile, which is not supported by the %s fan controller", entry.At, config.FanControllerConfig.Type),
+					fmt.Sprintf("Remove fan_profile from the schedule entry, or set fan_controller.type to %s", fancontroller.ControllerTypeLinear),
+				)
+			}
+		}
+	}
+
+	fanProfiles, err := fancontroller.NewProfiles(fanController, config.FanProfiles)
+	if err != nil {
+		return nil, err
+	}
+	if config.FanProfile != "" {
+		if err := fanProfiles.Activate(config.FanProfile); err != nil {
+			return nil, err
+		}
+	}
+
+	sched, err := schedule.New(config.Schedule, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range config.Schedule {
+		if entry.FanProfile != "" && !slices.Contains(fanProfiles.Names(), entry.FanProfile) {
+			return nil, humane.New(fmt.Sprintf("schedule entry at %s uses unknown fan profile %q", entry.At, entry.FanProfile),
+				fmt.Sprintf("available profiles are: [%s]", strings.Join(fanProfiles.Names(), ", ")),
+			)
+		}
+	}
+
+	fanSmoother, err := fancontroller.NewSmoother(config.FanControllerConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fanInputs, err := fancontroller.NewInputs(config.FanControllerConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Only the smart fan unit has an air flow temperature sensor
+	for _, input := range fanInputs {
+		if input.Source() == fancontroller.TemperatureSourceAirFlow && blade.GetFanUnitKind() != hal.FanUnitKindSmart {
+			return nil, humane.New(fmt.Sprintf("fan controller input %s reads the air flow temperature, but no smart fan unit was detected", input.Name()),
+				"Remove the airflow input from fan_controller.inputs, or install a smart fan unit",
+			)
+		}
+	}
+
+	fanFeedForward, err := fancontroller.NewFeedForward(config.FanControllerConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fanZeroRPM, err := fancontroller.NewZeroRPM(config.FanControllerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	fanCalibration, err := fancontroller.LoadCalibration(config.FanControllerConfig.Calibration.CalibrationPath())
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to load fan calibration, the fan speed is not clamped", humane.Zap(err)...)
+		fanCalibration = nil
+	}
+
+	fanHealthConfig := config.FanHealth
+	if fanHealthConfig.Enabled && blade.GetFanUnitKind() == hal.FanUnitKindStandardNoRPM {
+		log.FromContext(ctx).Warn("Fan health monitoring requires fan speed reporting, disabling it",
+			zap.String("hint", "enable hal.rpm_reporting_standard_fan_unit to monitor the fan health"),
+		)
+		fanHealthConfig.Enabled = false
+	}
+
+	// A threshold of 0 disables the critical temperature monitor
+	var criticalThreshold float64
+	if config.AutomaticCriticalMode {
+		criticalThreshold = float64(config.CriticalTemperatureThreshold)
+	}
+
+	a := &computeBladeAgent{
+		config:         config,
+		blade:          blade,
+		ledCorrection:  ledCorrection,
+		edgeLed:        ledengine.NewCompositor(ledengine.New(blade, hal.LedEdge), nil),
+		topLed:         ledengine.NewCompositor(ledengine.New(blade, hal.LedTop), nil),
+		fanController:  fanController,
+		fanProfiles:    fanProfiles,
+		fanSmoother:    fanSmoother,
+		fanInputs:      fanInputs,
+		fanFeedForward: fanFeedForward,
+		fanZeroRPM:     fanZeroRPM,
+		fanBoosts:      fancontroller.NewBoosts(clock),
+		schedule:       sched,
+		state:          agent.NewComputeBladeState(),
+		eventChan:      make(chan events.Event, 10),
+		agentInfo:      agentInfo,
+		criticalMonitor: agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{
+			Threshold:      criticalThreshold,
+			ResetThreshold: float64(config.CriticalResetTemperatureThreshold),
+			Dwell:          config.CriticalTemperatureDwell,
+		}, clock),
+		fanHealthMonitor: agent.NewFanHealthMonitor(fanHealthConfig, clock),
+		identifyTracker:  agent.NewIdentifyTracker(nil),
+		clock:            clock,
+	}
+
+	a.stealthMode.Store(config.StealthModeEnabled)
+	if expressionController, ok := fanController.(fancontroller.ExpressionFanController); ok {
+		expressionController.SetEnvironment(&expressionEnvironment{ctx: ctx, agent: a})
+	}
+	a.fanCalibrationResult.Store(fanCalibration)
+
+	for _, fault := range agent.AllFaults {
+		faultActive.WithLabelValues(fault.String()).Set(0)
+	}
+	if config.ExpectSmartFanUnit && blade.GetFanUnitKind() != hal.FanUnitKindSmart {
+		a.setFault(ctx, agent.FaultSmartFanUnitMissing, true, nil)
+	}
+
+	return a, nil
+}
+
 // RunAsync starts the agent in a separate goroutine and handles errors, allowing cancellation through the provided context.
 func (a *computeBladeAgent) RunAsync(ctx context.Context, cancel context.CancelCauseFunc) {
 	go func() {
@@ -106,7 +317,7 @@ func (a *computeBladeAgent) Run(origCtx context.Context) error {
 	a.state.RegisterEvent(events.NoopEvent)
 
 	// Set defaults
-	if err := a.blade.SetStealthMode(a.config.StealthModeEnabled); err != nil {
+	if err := a.blade.SetStealthMode(a.stealthMode.Load()); err != nil {
 		return err
 	}
 
@@ -127,6 +338,9 @@ func (a *computeBladeAgent) Run(origCtx context.Context) error {
 	// Start fan controller
 	go a.runFanController(ctx, cancelCtx)
 
+	// Start schedule
+	go a.runSchedule(ctx, cancelCtx)
+
 	// Start event handler
 	go a.runEventHandler(ctx, cancelCtx)
 
@@ -166,16 +380,11 @@ func (a *computeBladeAgent) runHal(ctx context.Context, cancel context.CancelCau
 	}
 }
 
-// runTopLedEngine runs the top LED engine
+// runTopLedEngine runs the top LED engine. Without any active layer, the top LED is off.
 // FIXME the top LED is only used to indicate emergency situations
 func (a *computeBladeAgent) runTopLedEngine(ctx context.Context, cancel context.CancelCauseFunc) {
 	log.FromContext(ctx).Info("Starting top LED engine")
-	if err := a.topLedEngine.SetPattern(ledengine.NewStaticPattern(led.Color{})); err != nil && !errors.Is(err, context.Canceled) {
-		log.FromContext(ctx).WithError(err).Error("Top LED engine failed")
-		cancel(err)
-	}
-
-	if err := a.topLedEngine.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+	if err := a.topLed.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
 		log.FromContext(ctx).WithError(err).Error("Top LED engine failed")
 		cancel(err)
 	}
@@ -185,12 +394,12 @@ func (a *computeBladeAgent) runTopLedEngine(ctx context.Context, cancel context.
 func (a *computeBladeAgent) runEdgeLedEngine(ctx context.Context, cancel context.CancelCauseFunc) {
 	log.FromContext(ctx).Info("Starting edge LED engine")
 
-	if err := a.edgeLedEngine.SetPattern(ledengine.NewStaticPattern(a.config.IdleLedColor)); err != nil && !errors.Is(err, context.Canceled) {
+	if err := a.edgeLed.SetPattern(ledengine.LayerIdle, ledengine.NewStaticPattern(a.idleLedColor(0))); err != nil && !errors.Is(err, context.Canceled) {
 		log.FromContext(ctx).WithError(err).Error("Edge LED engine failed")
 		cancel(err)
 	}
 
-	if err := a.edgeLedEngine.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+	if err := a.edgeLed.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
 		log.FromContext(ctx).WithError(err).Error("Edge LED engine failed")
 		cancel(err)
 	}
@@ -198,7 +407,6 @@ func (a *computeBladeAgent) runEdgeLedEngine(ctx context.Context, cancel context
 
 // runFanController initializes and manages a periodic task to control fan speed based on temperature readings.
 // The method uses a ticker to execute fan speed adjustments and handles context cancellation for cleanup.
-// If obtaining temperature or setting fan speed fails, appropriate error logs are recorded.
 func (a *computeBladeAgent) runFanController(ctx context.Context, cancel context.CancelCauseFunc) {
 	log.FromContext(ctx).Info("Starting fan controller")
 
@@ -219,18 +427,127 @@ func (a *computeBladeAgent) runFanController(ctx context.Context, cancel context
 		case <-ticker.C:
 		}
 
-		// Get temperature
-		temp, err := a.blade.GetTemperature()
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Error("Failed to get temperature")
-			temp = 100 // set to a high value to trigger the maximum speed defined by the fan curve
+		a.updateFanSpeed(ctx)
+	}
+}
+
+// updateFanSpeed derives the fan speed from the SoC temperature and any additional temperature inputs and applies it.
+// Every successful SoC temperature reading is also fed into the critical temperature monitor.
+// If obtaining temperature or setting fan speed fails, appropriate error logs are recorded.
+func (a *computeBladeAgent) updateFanSpeed(ctx context.Context) {
+	// Get temperature
+	temp, err := a.blade.GetTemperature()
+	a.setFault(ctx, agent.FaultTemperatureSensor, err != nil, err)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("Failed to get temperature")
+		temp = 100 // set to a high value to trigger the maximum speed defined by the fan curve
+	} else {
+		a.checkCriticalTemperature(ctx, temp)
+		a.updateIdleLed(ctx, temp)
+	}
+
+	// The fan calibration controls the fan, unless the blade gets too hot. A calibration can't start until the fan
+	// speed of this update is set.
+	releaseFan, ok := a.fanCalibration.holdFan()
+	if !ok {
+		if a.state.CriticalActive() {
+			log.FromContext(ctx).Warn("Blade in critical state, aborting fan calibration")
+			a.fanCalibration.stop()
 		}
-		// Derive fan speed from temperature
-		speed := a.fanController.GetFanSpeedPercent(temp)
-		// Set fan speed
-		if err := a.blade.SetFanSpeed(speed); err != nil {
-			log.FromContext(ctx).WithError(err).Error("Failed to set fan speed")
+		return
+	}
+	defer releaseFan()
+
+	// Derive fan speed from temperature. The hysteresis only dampens the linear fan curve, closed-loop controllers need
+	// the actual temperature.
+	controllerTemp := temp
+	if a.config.FanControllerConfig.Type == "" || a.config.FanControllerConfig.Type == fancontroller.ControllerTypeLinear {
+		controllerTemp = a.fanSmoother.Temperature(temp)
+	}
+	rawSpeed := a.fanController.GetFanSpeedPercent(controllerTemp)
+
+	// Combine with the additional temperature inputs, unless the fan speed is overridden. The expression fan controller
+	// already evaluated the input temperatures and has the final say.
+	if a.fanController.IsAutomaticSpeed() && len(a.fanInputs) > 0 && a.config.FanControllerConfig.Type != fancontroller.ControllerTypeExpression {
+		speeds := []uint8{rawSpeed}
+		for _, input := range a.fanInputs {
+			inputSpeed := input.GetFanSpeedPercent(a.readInputTemperature(ctx, input))
+			fanTargetInputPercent.WithLabelValues(input.Name()).Set(float64(inputSpeed))
+			speeds = append(speeds, inputSpeed)
+		}
+		rawSpeed = fancontroller.Aggregate(a.config.FanControllerConfig.Aggregation, speeds...)
+	}
+
+	// Anticipate load spikes from the SoC temperature slope, the fallback temperature is no sample
+	if a.fanController.IsAutomaticSpeed() && err == nil {
+		rawSpeed = a.fanFeedForward.Percent(temp, rawSpeed)
+		temperatureSlope.Set(a.fanFeedForward.Slope())
+	}
+
+	// Ramp rate limits avoid hunting of the automatic fan speed, but must never delay overrides, critical mode or
+	// the fallback speed of a failed temperature sensor
+	speed := rawSpeed
+	if a.fanController.IsAutomaticSpeed() && !a.state.CriticalActive() && err == nil {
+		speed = a.fanSmoother.Percent(rawSpeed)
+	} else {
+		a.fanSmoother.Reset(rawSpeed)
+	}
+	fanTargetRawPercent.Set(float64(rawSpeed))
+	fanTargetSmoothedPercent.Set(float64(speed))
+
+	// Stop the fan at low SoC temperatures, unless the fan speed is overridden
+	if a.fanController.IsAutomaticSpeed() {
+		speed = a.fanZeroRPM.Percent(temp, speed)
+	}
+
+	// Raise the fan speed to the highest active boost
+	speed = a.fanBoosts.Apply(speed)
+
+	// Never command a duty cycle the fan stalls at. Whether the fan is running is measured, so a stalled fan is
+	// restarted. Without a fan speed reading the fan counts as standing.
+	if calibration := a.fanCalibrationResult.Load(); calibration != nil {
+		rpm, rpmErr := a.blade.GetFanRPM()
+		if rpmErr != nil {
+			rpm = 0
 		}
+		speed = calibration.Clamp(speed, rpm)
+	}
+
+	// Set fan speed
+	err = a.blade.SetFanSpeed(speed)
+	a.setFault(ctx, agent.FaultFanControl, err != nil, err)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("Failed to set fan speed")
+		return
+	}
+	a.fanSpeed.Store(uint32(speed))
+
+	a.checkFanHealth(ctx, speed)
+}
+
+// checkCriticalTemperature feeds the temperature into the critical temperature monitor and emits the resulting event, if any.
+func (a *computeBladeAgent) checkCriticalTemperature(ctx context.Context, temp float64) {
+	event := a.criticalMonitor.Observe(temp, a.state.CriticalReasons().Has(agent.CriticalReasonTemperature))
+	if event == events.NoopEvent {
+		return
+	}
+
+	log.FromContext(ctx).Warn("Critical temperature state changed",
+		zap.Float64("temperature", temp),
+		zap.String("event", event.String()),
+	)
+
+	a.emitEvent(ctx, event)
+}
+
+// emitEvent sends an event raised by the agent itself to the event handler without blocking.
+// If the event channel is full, the event is dropped.
+func (a *computeBladeAgent) emitEvent(ctx context.Context, event events.Event) {
+	select {
+	case a.eventChan <- event:
+	default:
+		log.FromContext(ctx).Warn("Event dropped due to backlog", zap.String("event", event.String()))
+		droppedEventCounter.WithLabelValues(event.String()).Inc()
 	}
 }
 
@@ -278,6 +595,19 @@ func (a *computeBladeAgent) runEventHandler(ctx context.Context, cancel context.
 
 // runGRpcApi starts the gRPC server for the agent based on the configuration and gracefully handles errors or cancellation.
 func (a *computeBladeAgent) runGRpcApi(ctx context.Context, cancel context.CancelCauseFunc) {
+	// The API can't be served securely without certificates, the blade is controlled nevertheless
+	a.faultsMu.Lock()
+	certificatesMissing := a.faults.IsActive(agent.FaultCertificate)
+	a.faultsMu.Unlock()
+	if certificatesMissing {
+		log.FromContext(ctx).Warn("Certificates are unavailable, delaying the gRPC server until they are set up",
+			zap.Duration("retry_interval", certificateRetryInterval),
+		)
+		if !a.waitForCertificates(ctx) {
+			return
+		}
+	}
+
 	if len(a.config.Listen.Grpc) == 0 {
 		err := humane.New("no listen address provided",
 			"ensure you are passing a valid listen config to the grpc server",
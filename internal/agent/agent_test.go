@@ -0,0 +1,64 @@
+package internal_agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateFanSpeed_Expression(t *testing.T) {
+	t.Parallel()
+
+	config := fancontroller.Config{
+		Type:       fancontroller.ControllerTypeExpression,
+		Expression: `inputs.board > 90.0 ? 100.0 : 25.0`,
+		Inputs:     []fancontroller.InputConfig{{Name: "board", Source: fancontroller.TemperatureSourceSoC}},
+	}
+
+	blade := &hal.ComputeBladeHalMock{}
+	blade.On("GetTemperature").Return(80.0, nil)
+	blade.On("GetFanRPM").Return(2000.0, nil)
+	blade.On("GetPowerStatus").Return(hal.PowerStatus(hal.PowerPoe802at), nil)
+	blade.On("GetFanUnitKind").Return(hal.FanUnitKind(hal.FanUnitKindStandard))
+	blade.On("SetFanSpeed", uint8(25)).Once().Return(nil)
+
+	fanController, err := fancontroller.NewExpressionFanController(config, nil, nil)
+	require.Nil(t, err)
+	fanSmoother, err := fancontroller.NewSmoother(config, nil)
+	require.Nil(t, err)
+	fanInputs, err := fancontroller.NewInputs(config)
+	require.Nil(t, err)
+	fanFeedForward, err := fancontroller.NewFeedForward(config, nil)
+	require.Nil(t, err)
+	fanZeroRPM, err := fancontroller.NewZeroRPM(config)
+	require.Nil(t, err)
+
+	a := &computeBladeAgent{
+		config:           agent.ComputeBladeAgentConfig{FanControllerConfig: config},
+		blade:            blade,
+		topLed:           ledengine.NewCompositor(ledengine.New(blade, hal.LedTop), nil),
+		fanController:    fanController,
+		fanSmoother:      fanSmoother,
+		fanInputs:        fanInputs,
+		fanFeedForward:   fanFeedForward,
+		fanZeroRPM:       fanZeroRPM,
+		fanBoosts:        fancontroller.NewBoosts(nil),
+		state:            agent.NewComputeBladeState(),
+		criticalMonitor:  agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{}, nil),
+		fanHealthMonitor: agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{}, nil),
+	}
+	ctx := context.Background()
+	fanController.SetEnvironment(&expressionEnvironment{ctx: ctx, agent: a})
+
+	// The expression has the final say, the fan runs below what the input temperature would suggest
+	a.updateFanSpeed(ctx)
+	blade.AssertExpectations(t)
+
+	// The input temperature is read once for the expression, besides the SoC temperature
+	blade.AssertNumberOfCalls(t, "GetTemperature", 2)
+}
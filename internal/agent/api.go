@@ -3,18 +3,26 @@ package internal_agent
 import (
 	"context"
 	"crypto/tls"
+	"math"
+	"time"
 
 	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
 	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/sierrasoftworks/humane-errors-go"
 	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// certificateRetryInterval is the interval at which failed certificate setups are retried
+const certificateRetryInterval = time.Minute
+
 // setupGrpcServer initializes and configures the gRPC server with authentication, logging, and server options.
 func (a *computeBladeAgent) setupGrpcServer(ctx context.Context) error {
 	listenMode, err := ListenModeFromString(a.config.Listen.GrpcListenMode)
@@ -25,14 +33,16 @@ func (a *computeBladeAgent) setupGrpcServer(ctx context.Context) error {
 	var grpcOpts []grpc.ServerOption
 
 	if listenMode == ModeTcp && a.config.Listen.GrpcAuthenticated {
-		tlsCfg, err := createServerTLSConfig(ctx)
-		if err != nil {
-			return err
-		}
-		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		// The certificates may only become available once a retry succeeded, so they are looked up per connection
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+				return a.serverTLS.Load(), nil
+			},
+		})))
 
-		if err := EnsureAuthenticatedBladectlConfig(ctx, a.config.Listen.Grpc, listenMode); err != nil {
-			return err
+		if err := a.setupCertificates(ctx); err != nil {
+			// The blade keeps being controlled without the API, the fault is shown on the top LED
+			log.FromContext(ctx).Error("Failed to set up certificates, the gRPC server is disabled until they are available", humane.Zap(err)...)
 		}
 	} else {
 		if err := EnsureUnauthenticatedBladectlConfig(ctx, a.config.Listen.Grpc, listenMode); err != nil {
@@ -50,12 +60,49 @@ func (a *computeBladeAgent) setupGrpcServer(ctx context.Context) error {
 	return nil
 }
 
+// setupCertificates loads or generates the certificates of the authenticated gRPC server and the bladectl config.
+// FaultCertificate is raised while this fails and cleared once it succeeds.
+func (a *computeBladeAgent) setupCertificates(ctx context.Context) humane.Error {
+	tlsCfg, err := createServerTLSConfig(ctx)
+	if err == nil {
+		err = EnsureAuthenticatedBladectlConfig(ctx, a.config.Listen.Grpc, ModeTcp)
+	}
+	if err != nil {
+		a.setFault(ctx, agent.FaultCertificate, true, err)
+		return err
+	}
+
+	a.serverTLS.Store(tlsCfg)
+	a.setFault(ctx, agent.FaultCertificate, false, nil)
+	return nil
+}
+
+// waitForCertificates retries the certificate setup until it succeeds. It returns false if ctx is canceled first.
+func (a *computeBladeAgent) waitForCertificates(ctx context.Context) bool {
+	ticker := time.NewTicker(certificateRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			if err := a.setupCertificates(ctx); err != nil {
+				log.FromContext(ctx).Warn("Failed to set up certificates, retrying", humane.Zap(err)...)
+				continue
+			}
+			log.FromContext(ctx).Info("Certificates are available, enabling the gRPC server")
+			return true
+		}
+	}
+}
+
 // createServerTLSConfig creates and returns a TLS configuration for a server, enforcing client authentication.
-// It generates or loads the necessary certificates and certificate pools, logging fatal errors if certificate loading fails.
-func createServerTLSConfig(ctx context.Context) (*tls.Config, error) {
+// It generates or loads the necessary certificates and certificate pools.
+func createServerTLSConfig(ctx context.Context) (*tls.Config, humane.Error) {
 	cert, certPool, err := EnsureServerCertificate(ctx)
 	if err != nil {
-		log.FromContext(ctx).WithError(err).Fatal("failed to load server key pair")
+		return nil, humane.Wrap(err, "failed to load server key pair")
 	}
 	return &tls.Config{
 		Certificates: []tls.Certificate{cert},
@@ -71,6 +118,11 @@ func (a *computeBladeAgent) EmitEvent(ctx context.Context, req *bladeapiv1alpha1
 		return nil, err
 	}
 
+	// Identify mode with options is applied by the identify event
+	if event == events.IdentifyEvent && req.GetIdentify() != nil {
+		return a.SetIdentify(ctx, req.GetIdentify())
+	}
+
 	select {
 	case a.eventChan <- event:
 		return &emptypb.Empty{}, nil
@@ -79,19 +131,71 @@ func (a *computeBladeAgent) EmitEvent(ctx context.Context, req *bladeapiv1alpha1
 	}
 }
 
-// SetFanSpeed sets the fan speed
-func (a *computeBladeAgent) SetFanSpeed(_ context.Context, req *bladeapiv1alpha1.SetFanSpeedRequest) (*emptypb.Empty, error) {
+// SetFanSpeed sets the fan speed, optionally leased for a duration after which automatic control resumes
+func (a *computeBladeAgent) SetFanSpeed(ctx context.Context, req *bladeapiv1alpha1.SetFanSpeedRequest) (*emptypb.Empty, error) {
 	if a.state.CriticalActive() {
 		return &emptypb.Empty{}, humane.New("cannot set fan speed while the blade is in a critical state", "improve cooling on your blade before attempting to overwrite the fan speed")
 	}
+	if req.GetDurationSeconds() < 0 {
+		return &emptypb.Empty{}, humane.New("fan speed override duration must not be negative",
+			"omit the duration to keep the override until it is removed",
+		)
+	}
+
+	percent, err := percentFromProto("fan speed", req.GetPercent())
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	opts := &fancontroller.FanOverrideOpts{
+		Percent: percent,
+		Owner:   req.GetOwner(),
+	}
+	if req.GetDurationSeconds() > 0 {
+		opts.ExpiresAt = a.clock.Now().Add(time.Duration(req.GetDurationSeconds()) * time.Second)
+	}
+
+	a.fanController.Override(opts)
+	log.FromContext(ctx).Info("Fan speed overridden",
+		zap.Uint8("percent", opts.Percent),
+		zap.String("owner", opts.Owner),
+		zap.Time("expires_at", opts.ExpiresAt),
+	)
 
-	a.fanController.Override(&fancontroller.FanOverrideOpts{Percent: uint8(req.GetPercent())})
 	return &emptypb.Empty{}, nil
 }
 
-// SetFanSpeedAuto sets the fan speed to automatic mode
+// BoostFan raises the fan speed to at least the requested percent for the requested duration.
+// The boost is applied with the next fan controller update.
+func (a *computeBladeAgent) BoostFan(ctx context.Context, req *bladeapiv1alpha1.BoostFanRequest) (*emptypb.Empty, error) {
+	percent, err := percentFromProto("fan boost", req.GetPercent())
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	boost, err := a.fanBoosts.Add(percent, time.Duration(req.GetDurationSeconds())*time.Second)
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+	log.FromContext(ctx).Info("Fan boosted",
+		zap.Uint8("percent", boost.Percent),
+		zap.Time("expires_at", boost.ExpiresAt),
+	)
+
+	return &emptypb.Empty{}, nil
+}
+
+// SetFanSpeedAuto sets the fan speed to automatic mode. In critical mode, the fan keeps running at 100% and returns to
+// automatic mode once critical mode is cleared.
 func (a *computeBladeAgent) SetFanSpeedAuto(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
-	a.fanController.Override(nil)
+	a.criticalOverrideMu.Lock()
+	defer a.criticalOverrideMu.Unlock()
+
+	if a.criticalOverride {
+		a.preCriticalOverride = nil
+	} else {
+		a.fanController.Override(nil)
+	}
 	return &emptypb.Empty{}, nil
 }
 
@@ -100,14 +204,20 @@ func (a *computeBladeAgent) SetStealthMode(_ context.Context, req *bladeapiv1alp
 	if a.state.CriticalActive() {
 		return &emptypb.Empty{}, humane.New("cannot set stealth mode while the blade is in a critical state", "improve cooling on your blade before attempting to enable stealth mode again")
 	}
-	return &emptypb.Empty{}, a.blade.SetStealthMode(req.GetEnable())
+	if err := a.blade.SetStealthMode(req.GetEnable()); err != nil {
+		return &emptypb.Empty{}, err
+	}
+	a.stealthMode.Store(req.GetEnable())
+
+	return &emptypb.Empty{}, nil
 }
 
 // GetStatus aggregates the status of the blade
-func (a *computeBladeAgent) GetStatus(_ context.Context, _ *emptypb.Empty) (*bladeapiv1alpha1.StatusResponse, error) {
+func (a *computeBladeAgent) GetStatus(ctx context.Context, _ *emptypb.Empty) (*bladeapiv1alpha1.StatusResponse, error) {
+	// A missing fan speed is reported as fan failure, it must not prevent reporting the status
 	rpm, err := a.blade.GetFanRPM()
 	if err != nil {
-		return nil, err
+		log.FromContext(ctx).WithError(err).Warn("Failed to get fan speed")
 	}
 
 	temp, err := a.blade.GetTemperature()
@@ -120,15 +230,51 @@ func (a *computeBladeAgent) GetStatus(_ context.Context, _ *emptypb.Empty) (*bla
 		return nil, err
 	}
 
-	steps := a.fanController.Steps()
-	fanCurveSteps := make([]*bladeapiv1alpha1.FanCurveStep, len(steps))
-	for idx, step := range steps {
-		fanCurveSteps[idx] = &bladeapiv1alpha1.FanCurveStep{
-			Temperature: int64(step.Temperature),
-			Percent:     uint32(step.Percent),
+	// Report the fan speed actually applied, which may differ from the SoC curve due to smoothing and additional inputs
+	fanPercent := a.fanSpeed.Load()
+	if !a.fanController.IsAutomaticSpeed() {
+		fanPercent = uint32(a.fanController.GetFanSpeedPercent(temp))
+	}
+
+	var fanOverrideOwner string
+	var fanOverrideRemaining int64
+	if override := a.fanController.ActiveOverride(); override != nil {
+		fanOverrideOwner = override.Owner
+		// Round up, so an active lease never reports 0 (which indicates an override without expiry)
+		fanOverrideRemaining = int64(math.Ceil(override.Remaining(a.clock.Now()).Seconds()))
+	}
+
+	var fanBoostPercent uint32
+	var fanBoostRemaining int64
+	if boost, ok := a.fanBoosts.Active(); ok {
+		fanBoostPercent = uint32(boost.Percent)
+		fanBoostRemaining = int64(math.Ceil(boost.ExpiresAt.Sub(a.clock.Now()).Seconds()))
+	}
+
+	var nextScheduleTransition *bladeapiv1alpha1.ScheduleTransition
+	if transition, ok := a.schedule.Next(); ok {
+		nextScheduleTransition = &bladeapiv1alpha1.ScheduleTransition{
+			Time:        transition.Time.Unix(),
+			FanProfile:  transition.FanProfile,
+			StealthMode: transition.StealthMode,
+		}
+		if transition.LedBrightness != nil {
+			ledBrightness := uint32(*transition.LedBrightness)
+			nextScheduleTransition.LedBrightness = &ledBrightness
 		}
 	}
 
+	var identifyRequester string
+	var identifySince, identifyRemaining int64
+	if a.state.IdentifyActive() {
+		requester, since, remaining := a.identifyTracker.Status()
+		identifyRequester = requester
+		if !since.IsZero() {
+			identifySince = since.Unix()
+		}
+		identifyRemaining = int64(math.Ceil(remaining.Seconds()))
+	}
+
 	versionInfo := &bladeapiv1alpha1.VersionInfo{
 		Version: a.agentInfo.Version,
 		Commit:  a.agentInfo.Commit,
@@ -141,15 +287,87 @@ func (a *computeBladeAgent) GetStatus(_ context.Context, _ *emptypb.Empty) (*bla
 		CriticalActive:               a.state.CriticalActive(),
 		Temperature:                  int64(temp),
 		FanRpm:                       int64(rpm),
-		FanPercent:                   uint32(a.fanController.GetFanSpeedPercent(temp)),
+		FanPercent:                   fanPercent,
 		FanSpeedAutomatic:            a.fanController.IsAutomaticSpeed(),
 		PowerStatus:                  bladeapiv1alpha1.PowerStatus(powerStatus),
-		FanCurveSteps:                fanCurveSteps,
+		FanCurveSteps:                fanCurveToProto(a.fanController.Steps()),
 		CriticalTemperatureThreshold: int64(a.config.CriticalTemperatureThreshold),
 		Version:                      versionInfo,
+		FanProfile:                   a.fanProfiles.Active(),
+		NextScheduleTransition:       nextScheduleTransition,
+		FanFailure:                   bladeapiv1alpha1.FanFailure(a.fanHealthMonitor.Failure()),
+		FanOverrideOwner:             fanOverrideOwner,
+		FanOverrideRemainingSeconds:  fanOverrideRemaining,
+		FanBoostPercent:              fanBoostPercent,
+		FanBoostRemainingSeconds:     fanBoostRemaining,
+		LedBrightnessPercent:         uint32(a.ledCorrection.Brightness()),
+		Faults:                       faultsToProto(a.faults.Active()),
+		IdentifyRequester:            identifyRequester,
+		IdentifySince:                identifySince,
+		IdentifyRemainingSeconds:     identifyRemaining,
+		ComputeModule:                a.blade.ComputeModule(),
+	}, nil
+}
+
+// SetFanCurve validates and replaces the fan curve, optionally persisting it to the configuration file
+func (a *computeBladeAgent) SetFanCurve(ctx context.Context, req *bladeapiv1alpha1.SetFanCurveRequest) (*emptypb.Empty, error) {
+	steps := make([]fancontroller.Step, len(req.GetSteps()))
+	for idx, step := range req.GetSteps() {
+		percent, err := percentFromProto("fan curve step", step.GetPercent())
+		if err != nil {
+			return &emptypb.Empty{}, err
+		}
+		steps[idx] = fancontroller.Step{
+			Temperature: float64(step.GetTemperature()),
+			Percent:     percent,
+		}
+	}
+
+	if err := a.fanProfiles.SetSteps(steps); err != nil {
+		return &emptypb.Empty{}, err
+	}
+	log.FromContext(ctx).Info("Fan curve updated", zap.Any("steps", steps))
+
+	if req.GetPersist() {
+		if err := agent.PersistFanCurve(a.config.ConfigFile, a.fanController.Steps()); err != nil {
+			return &emptypb.Empty{}, err
+		}
+		log.FromContext(ctx).Info("Fan curve persisted", zap.String("path", a.config.ConfigFile))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetFanCurve returns the current fan curve
+func (a *computeBladeAgent) GetFanCurve(_ context.Context, _ *emptypb.Empty) (*bladeapiv1alpha1.FanCurveResponse, error) {
+	return &bladeapiv1alpha1.FanCurveResponse{
+		Steps:                        fanCurveToProto(a.fanController.Steps()),
+		CriticalTemperatureThreshold: int64(a.config.CriticalTemperatureThreshold),
 	}, nil
 }
 
+// SetFanProfile switches the fan curve to the named fan profile
+func (a *computeBladeAgent) SetFanProfile(ctx context.Context, req *bladeapiv1alpha1.SetFanProfileRequest) (*emptypb.Empty, error) {
+	if err := a.fanProfiles.Activate(req.GetName()); err != nil {
+		return &emptypb.Empty{}, err
+	}
+	log.FromContext(ctx).Info("Fan profile activated", zap.String("profile", req.GetName()))
+
+	return &emptypb.Empty{}, nil
+}
+
+// fanCurveToProto converts fan controller steps to their API representation
+func fanCurveToProto(steps []fancontroller.Step) []*bladeapiv1alpha1.FanCurveStep {
+	fanCurveSteps := make([]*bladeapiv1alpha1.FanCurveStep, len(steps))
+	for idx, step := range steps {
+		fanCurveSteps[idx] = &bladeapiv1alpha1.FanCurveStep{
+			Temperature: int64(step.Temperature),
+			Percent:     uint32(step.Percent),
+		}
+	}
+	return fanCurveSteps
+}
+
 // WaitForIdentifyConfirm blocks until the identify confirmation process is completed or an error occurs.
 func (a *computeBladeAgent) WaitForIdentifyConfirm(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, a.state.WaitForIdentifyConfirm(ctx)
@@ -0,0 +1,125 @@
+package internal_agent
+
+import (
+	"context"
+	"sync"
+
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"go.uber.org/zap"
+)
+
+// fanCalibrationRun tracks the running fan calibration, if any
+type fanCalibrationRun struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// start registers a new calibration and returns its context, or false if a calibration is already running
+func (r *fanCalibrationRun) start(ctx context.Context) (context.Context, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return nil, false
+	}
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	return ctx, true
+}
+
+// stop aborts the running calibration, if any, and releases its context
+func (r *fanCalibrationRun) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// holdFan keeps calibrations from starting until release is called, so the fan controller can set the fan speed
+// without racing the first duty cycle of a calibration. It returns false if a calibration is already running.
+func (r *fanCalibrationRun) holdFan() (release func(), ok bool) {
+	r.mu.Lock()
+	if r.cancel != nil {
+		r.mu.Unlock()
+		return nil, false
+	}
+
+	return r.mu.Unlock, true
+}
+
+// CalibrateFan sweeps the fan through the requested duty cycles and stores the resulting calibration.
+// The fan controller is paused while the calibration is running, critical mode aborts the calibration.
+func (a *computeBladeAgent) CalibrateFan(ctx context.Context, req *bladeapiv1alpha1.CalibrateFanRequest) (*bladeapiv1alpha1.CalibrateFanResponse, error) {
+	if a.state.CriticalActive() {
+		return nil, humane.New("cannot calibrate the fan while the blade is in a critical state",
+			"improve cooling on your blade before attempting to calibrate the fan",
+		)
+	}
+
+	config := a.config.FanControllerConfig.Calibration
+	if len(req.GetSteps()) > 0 {
+		config.Steps = make([]uint8, len(req.GetSteps()))
+		for idx, step := range req.GetSteps() {
+			percent, err := percentFromProto("fan calibration step", step)
+			if err != nil {
+				return nil, err
+			}
+			config.Steps[idx] = percent
+		}
+	}
+
+	calibrationCtx, ok := a.fanCalibration.start(ctx)
+	if !ok {
+		return nil, humane.New("fan calibration is already running",
+			"wait for the running calibration to complete",
+		)
+	}
+	defer a.fanCalibration.stop()
+
+	// The spin-up kick would start the fan at full duty on every step, hiding the duty cycle the fan starts at
+	if err := a.blade.SetFanSpinUpKick(false); err != nil {
+		return nil, humane.Wrap(err, "failed to disable the fan spin-up kick for the calibration")
+	}
+	defer func() {
+		if err := a.blade.SetFanSpinUpKick(true); err != nil {
+			log.FromContext(ctx).WithError(err).Error("Failed to re-enable the fan spin-up kick")
+		}
+	}()
+
+	log.FromContext(ctx).Info("Starting fan calibration", zap.Any("steps", config.Steps))
+	calibration, err := fancontroller.Calibrate(calibrationCtx, a.blade, config, nil)
+	if err != nil {
+		log.FromContext(ctx).Error("Fan calibration failed", humane.Zap(err)...)
+		return nil, err
+	}
+
+	if err := calibration.Save(config.CalibrationPath()); err != nil {
+		return nil, err
+	}
+	a.fanCalibrationResult.Store(calibration)
+
+	log.FromContext(ctx).Info("Fan calibration complete",
+		zap.Uint8("spin_up_percent", calibration.SpinUpPercent),
+		zap.Uint8("min_running_percent", calibration.MinRunningPercent),
+	)
+
+	points := make([]*bladeapiv1alpha1.FanCalibrationPoint, len(calibration.Points))
+	for idx, point := range calibration.Points {
+		points[idx] = &bladeapiv1alpha1.FanCalibrationPoint{
+			Percent: uint32(point.Percent),
+			Rpm:     int64(point.RPM),
+		}
+	}
+
+	return &bladeapiv1alpha1.CalibrateFanResponse{
+		Points:            points,
+		SpinUpPercent:     uint32(calibration.SpinUpPercent),
+		MinRunningPercent: uint32(calibration.MinRunningPercent),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package internal_agent
+
+import (
+	"context"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// fanExpressionErrors is a prometheus counter that counts the failed evaluations of the fan expression
+var fanExpressionErrors = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "computeblade_agent",
+	Name:      "fan_expression_errors_count",
+	Help:      "Number of failed fan expression evaluations, the fan runs at 100% whenever the evaluation fails",
+})
+
+// expressionEnvironment provides the blade state to the expression fan controller
+type expressionEnvironment struct {
+	ctx   context.Context
+	agent *computeBladeAgent
+}
+
+// InputTemperatures reads the temperatures of all additional fan controller inputs
+func (e *expressionEnvironment) InputTemperatures() map[string]float64 {
+	temperatures := make(map[string]float64, len(e.agent.fanInputs))
+	for _, input := range e.agent.fanInputs {
+		temperatures[input.Name()] = e.agent.readInputTemperature(e.ctx, input)
+	}
+	return temperatures
+}
+
+// FanRPM returns the current fan speed, or 0 if it cannot be read
+func (e *expressionEnvironment) FanRPM() float64 {
+	rpm, err := e.agent.blade.GetFanRPM()
+	if err != nil {
+		log.FromContext(e.ctx).WithError(err).Warn("Failed to get fan speed for the fan expression")
+		return 0
+	}
+	return rpm
+}
+
+// PowerStatus returns the power status of the blade
+func (e *expressionEnvironment) PowerStatus() string {
+	powerStatus, err := e.agent.blade.GetPowerStatus()
+	if err != nil {
+		log.FromContext(e.ctx).WithError(err).Warn("Failed to get power status for the fan expression")
+	}
+	return powerStatus.String()
+}
+
+// ExpressionFailed logs the failed evaluation of the fan expression
+func (e *expressionEnvironment) ExpressionFailed(err error) {
+	log.FromContext(e.ctx).WithError(err).Error("Failed to evaluate fan expression, setting fan speed to 100%")
+	fanExpressionErrors.Inc()
+}
@@ -0,0 +1,58 @@
+package internal_agent
+
+import (
+	"context"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// fanFailure is a prometheus gauge exposing the fan failure detected by the fan health monitor
+var fanFailure = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "computeblade_agent",
+	Name:      "fan_failure",
+	Help:      "Fan failure detected by the fan health monitor (label values are stall, tach_missing, rpm_out_of_range)",
+}, []string{"type"})
+
+// checkFanHealth feeds the commanded and measured fan speed into the fan health monitor and emits the resulting event, if any.
+// The event is emitted again on every check until the blade state follows the monitor, so a dropped event isn't lost.
+func (a *computeBladeAgent) checkFanHealth(ctx context.Context, speed uint8) {
+	rpm, err := a.blade.GetFanRPM()
+	event := a.fanHealthMonitor.Observe(speed, rpm, err, a.state.CriticalReasons().Has(agent.CriticalReasonFanFailure))
+
+	// The smart fan unit reports the fan speed periodically, without reports it is gone
+	if a.blade.GetFanUnitKind() == hal.FanUnitKindSmart {
+		a.setFault(ctx, agent.FaultSmartFanUnitMissing, err != nil, err)
+	}
+
+	failure := a.fanHealthMonitor.Failure()
+	for _, f := range []agent.FanFailure{agent.FanFailureStall, agent.FanFailureTachMissing, agent.FanFailureRPMOutOfRange} {
+		if f == failure {
+			fanFailure.WithLabelValues(f.String()).Set(1)
+		} else {
+			fanFailure.WithLabelValues(f.String()).Set(0)
+		}
+	}
+
+	if event == events.NoopEvent {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	if err != nil {
+		logger = logger.WithError(err)
+	}
+	logger.Warn("Fan health state changed",
+		zap.Uint8("percent", speed),
+		zap.Float64("rpm", rpm),
+		zap.String("failure", failure.String()),
+		zap.String("event", event.String()),
+	)
+
+	a.emitEvent(ctx, event)
+}
@@ -0,0 +1,80 @@
+package internal_agent
+
+import (
+	"context"
+
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// faultActive is a prometheus gauge exposing the active agent faults
+var faultActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "computeblade_agent",
+	Name:      "fault",
+	Help:      "Active agent faults (label values are temperature_sensor, fan_control, smart_fan_unit_missing, certificate)",
+}, []string{"type"})
+
+// defaultFaultLedColor is the color the blink codes are shown in if no fault LED color is configured
+var defaultFaultLedColor = led.Color{Red: 48, Green: 16}
+
+// setFault raises or clears the fault and updates the blink codes shown on the top LED.
+// The error that caused the fault, if any, is logged when the fault is raised.
+func (a *computeBladeAgent) setFault(ctx context.Context, fault agent.Fault, active bool, cause error) {
+	a.faultsMu.Lock()
+	defer a.faultsMu.Unlock()
+
+	if !a.faults.Set(fault, active) {
+		return
+	}
+
+	fields := []zap.Field{zap.String("fault", fault.String()), zap.Uint8("blink_code", fault.BlinkCode())}
+	if active {
+		faultActive.WithLabelValues(fault.String()).Set(1)
+		logger := log.FromContext(ctx)
+		if cause != nil {
+			logger = logger.WithError(cause)
+		}
+		logger.Warn("Fault raised", fields...)
+	} else {
+		faultActive.WithLabelValues(fault.String()).Set(0)
+		log.FromContext(ctx).Info("Fault cleared", fields...)
+	}
+
+	if err := a.showFaults(); err != nil {
+		log.FromContext(ctx).WithError(err).Error("Failed to show fault blink codes")
+	}
+}
+
+// showFaults flashes the blink codes of all active faults on the top LED, one after another
+func (a *computeBladeAgent) showFaults() error {
+	faults := a.faults.Active()
+	if len(faults) == 0 {
+		return a.topLed.Clear(ledengine.LayerFault)
+	}
+
+	codes := make([]uint8, len(faults))
+	for idx, fault := range faults {
+		codes[idx] = fault.BlinkCode()
+	}
+
+	color := a.config.FaultLedColor
+	if color == (led.Color{}) {
+		color = defaultFaultLedColor
+	}
+	return a.topLed.SetPattern(ledengine.LayerFault, ledengine.NewBlinkCodePattern(led.Color{}, color, codes...))
+}
+
+// faultsToProto converts the faults to their API representation
+func faultsToProto(faults []agent.Fault) []bladeapiv1alpha1.Fault {
+	result := make([]bladeapiv1alpha1.Fault, len(faults))
+	for idx, fault := range faults {
+		result[idx] = bladeapiv1alpha1.Fault(fault)
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
@@ -22,12 +23,18 @@ func (a *computeBladeAgent) handleEvent(ctx context.Context, event events.Event)
 
 	// Dispatch incoming events to the right handler(s)
 	switch event {
-	case events.CriticalEvent:
+	case events.CriticalEvent, events.CriticalTemperatureEvent:
 		// Handle critical event
 		return a.handleCriticalActive(ctx)
-	case events.CriticalResetEvent:
-		// Handle critical event
-		return a.handleCriticalReset(ctx)
+	case events.CriticalResetEvent, events.CriticalTemperatureResetEvent:
+		// Handle critical reset event
+		return a.handleCriticalReasonCleared(ctx)
+	case events.FanFailureEvent:
+		// Handle fan failure event
+		return a.handleFanFailure(ctx)
+	case events.FanFailureResetEvent:
+		// Handle fan failure reset event
+		return a.handleFanFailureReset(ctx)
 	case events.IdentifyEvent:
 		// Handle identify event
 		return a.handleIdentifyActive(ctx)
@@ -39,6 +46,11 @@ func (a *computeBladeAgent) handleEvent(ctx context.Context, event events.Event)
 		event := events.Event(events.IdentifyEvent)
 		if a.state.IdentifyActive() {
 			event = events.Event(events.IdentifyConfirmEvent)
+		} else {
+			request := a.defaultIdentifyRequest(edgeButtonRequester)
+			a.identify.mu.Lock()
+			a.identify.pending = &request
+			a.identify.mu.Unlock()
 		}
 		select {
 		case a.eventChan <- event:
@@ -52,51 +64,94 @@ func (a *computeBladeAgent) handleEvent(ctx context.Context, event events.Event)
 	return nil
 }
 
-// handleIdentifyActive is responsible for handling the identify event by setting a burst LED pattern based on the configuration.
-func (a *computeBladeAgent) handleIdentifyActive(ctx context.Context) error {
-	log.FromContext(ctx).Info("Identify active")
-	return a.edgeLedEngine.SetPattern(ledengine.NewBurstPattern(led.Color{}, a.config.IdentifyLedColor))
-}
-
-// handleIdentifyConfirm handles the confirmation of an identify event by updating the LED engine with a static idle pattern.
-func (a *computeBladeAgent) handleIdentifyConfirm(ctx context.Context) error {
-	log.FromContext(ctx).Info("Identify confirmed/cleared")
-	return a.edgeLedEngine.SetPattern(ledengine.NewStaticPattern(a.config.IdleLedColor))
-}
-
 // handleCriticalActive handles the system's response to a critical state by adjusting fan speed and LED indications.
 // It sets the fan speed to 100%, disables stealth mode, and applies a critical LED pattern.
 // Returns any errors encountered during the process as a combined error.
 func (a *computeBladeAgent) handleCriticalActive(ctx context.Context) error {
 	log.FromContext(ctx).Warn("Blade in critical state, setting fan speed to 100% and turning on LEDs")
+	return a.enterCriticalMode(ledengine.LayerCritical, ledengine.NewSlowBlinkPattern(led.Color{}, a.config.CriticalLedColor))
+}
+
+// handleFanFailure handles a failed fan by entering critical mode. The top LED bursts instead of blinking slowly,
+// to tell a failed fan apart from an overheating blade.
+func (a *computeBladeAgent) handleFanFailure(ctx context.Context) error {
+	log.FromContext(ctx).Warn("Fan failure detected, setting fan speed to 100% and turning on LEDs",
+		zap.String("failure", a.fanHealthMonitor.Failure().String()),
+	)
+	return a.enterCriticalMode(ledengine.LayerFanFailure, ledengine.NewBurstPattern(led.Color{}, a.config.CriticalLedColor))
+}
 
-	// Set fan speed to 100%
-	a.fanController.Override(&fancontroller.FanOverrideOpts{Percent: 100})
+// handleFanFailureReset handles the recovery of a failed fan. Critical mode is only cleared if the failed fan was the
+// only reason for it.
+func (a *computeBladeAgent) handleFanFailureReset(ctx context.Context) error {
+	log.FromContext(ctx).Info("Fan recovered")
+	return a.handleCriticalReasonCleared(ctx)
+}
+
+// handleCriticalReasonCleared handles a reason for critical mode being cleared. Critical mode is reset once no reason
+// is left, otherwise only the top LED pattern of the cleared reason is removed.
+func (a *computeBladeAgent) handleCriticalReasonCleared(ctx context.Context) error {
+	reasons := a.state.CriticalReasons()
+	if reasons == 0 {
+		return a.handleCriticalReset(ctx)
+	}
+
+	log.FromContext(ctx).Warn("Keeping the blade in critical mode", zap.String("reasons", reasons.String()))
+
+	var err error
+	if !reasons.Has(agent.CriticalReasonFanFailure) {
+		err = a.topLed.Clear(ledengine.LayerFanFailure)
+	}
+	if !reasons.Has(agent.CriticalReasonManual | agent.CriticalReasonTemperature) {
+		err = errors.Join(err, a.topLed.Clear(ledengine.LayerCritical))
+	}
+	return err
+}
+
+// enterCriticalMode sets the fan speed to 100%, disables stealth mode, ends identify mode, and shows the given pattern
+// on the top LED layer.
+func (a *computeBladeAgent) enterCriticalMode(topLedLayer ledengine.Layer, topLedPattern ledengine.BlinkPattern) error {
+	// Set fan speed to 100%, keeping the override it replaces
+	a.criticalOverrideMu.Lock()
+	if !a.criticalOverride {
+		a.preCriticalOverride = a.fanController.ActiveOverride()
+		a.criticalOverride = true
+	}
+	a.fanController.Override(&fancontroller.FanOverrideOpts{Percent: 100, Owner: "critical-mode"})
+	a.criticalOverrideMu.Unlock()
 
 	// Disable stealth mode (turn on LEDs)
 	setStealthModeError := a.blade.SetStealthMode(false)
 
+	// Critical mode ends identify mode, so the edge LED must not return to it once critical mode is cleared
+	a.identifyTracker.Clear()
+	clearIdentifyErr := a.edgeLed.Clear(ledengine.LayerIdentify)
+
 	// Set critical pattern for top LED
-	setPatternTopLedErr := a.topLedEngine.SetPattern(
-		ledengine.NewSlowBlinkPattern(led.Color{}, a.config.CriticalLedColor),
-	)
+	setPatternTopLedErr := a.topLed.SetPattern(topLedLayer, topLedPattern)
 	// Combine errors, but don't stop execution flow for now
-	return errors.Join(setStealthModeError, setPatternTopLedErr)
+	return errors.Join(setStealthModeError, clearIdentifyErr, setPatternTopLedErr)
 }
 
-// handleCriticalReset handles the reset of a critical state by restoring default hardware settings for fans and LEDs.
+// handleCriticalReset handles the reset of a critical state by restoring the fan speed override, stealth mode and LEDs
+// from before critical mode.
 func (a *computeBladeAgent) handleCriticalReset(ctx context.Context) error {
 	log.FromContext(ctx).Info("Critical state cleared, setting fan speed to default and restoring LEDs to default state")
-	// Reset fan controller overrides
-	a.fanController.Override(nil)
+	// Restore the fan speed override that was active before critical mode. An expired lease is cleared by the fan
+	// controller.
+	a.criticalOverrideMu.Lock()
+	a.fanController.Override(a.preCriticalOverride)
+	a.preCriticalOverride = nil
+	a.criticalOverride = false
+	a.criticalOverrideMu.Unlock()
 
 	// Reset stealth mode
-	if err := a.blade.SetStealthMode(a.config.StealthModeEnabled); err != nil {
+	if err := a.blade.SetStealthMode(a.stealthMode.Load()); err != nil {
 		return err
 	}
 
-	// Set top LED off
-	if err := a.topLedEngine.SetPattern(ledengine.NewStaticPattern(led.Color{})); err != nil {
+	// Remove the critical LED layers, turning off the top LED
+	if err := errors.Join(a.topLed.Clear(ledengine.LayerCritical), a.topLed.Clear(ledengine.LayerFanFailure)); err != nil {
 		return err
 	}
 
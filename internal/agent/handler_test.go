@@ -0,0 +1,149 @@
+package internal_agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleEvent_CriticalEndsIdentify(t *testing.T) {
+	t.Parallel()
+
+	blade := &hal.ComputeBladeHalMock{}
+	blade.On("SetStealthMode", mock.Anything).Return(nil)
+
+	fanController, err := fancontroller.NewLinearFanController(fancontroller.Config{}, nil)
+	require.Nil(t, err)
+
+	a := &computeBladeAgent{
+		blade:            blade,
+		edgeLed:          ledengine.NewCompositor(ledengine.New(blade, hal.LedEdge), nil),
+		topLed:           ledengine.NewCompositor(ledengine.New(blade, hal.LedTop), nil),
+		fanController:    fanController,
+		state:            agent.NewComputeBladeState(),
+		identifyTracker:  agent.NewIdentifyTracker(nil),
+		fanHealthMonitor: agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{}, nil),
+	}
+	ctx := context.Background()
+
+	require.NoError(t, a.handleEvent(ctx, events.IdentifyEvent))
+	layer, ok := a.edgeLed.Active()
+	assert.True(t, ok)
+	assert.Equal(t, ledengine.LayerIdentify, layer)
+	assert.True(t, a.state.IdentifyActive())
+
+	// Critical mode ends identify mode, the edge LED doesn't return to it once critical mode is cleared
+	require.NoError(t, a.handleEvent(ctx, events.CriticalEvent))
+	assert.False(t, a.state.IdentifyActive())
+	_, ok = a.edgeLed.Active()
+	assert.False(t, ok)
+
+	require.NoError(t, a.handleEvent(ctx, events.CriticalResetEvent))
+	assert.False(t, a.state.IdentifyActive())
+	_, ok = a.edgeLed.Active()
+	assert.False(t, ok)
+	requester, since, _ := a.identifyTracker.Status()
+	assert.Empty(t, requester)
+	assert.True(t, since.IsZero())
+}
+
+// newCriticalOverrideAgent creates an agent to enter and leave critical mode, with fan speed overrides expiring on clk
+func newCriticalOverrideAgent(t *testing.T, clk *manualClock) *computeBladeAgent {
+	t.Helper()
+
+	blade := &hal.ComputeBladeHalMock{}
+	blade.On("SetStealthMode", mock.Anything).Return(nil)
+
+	fanController, err := fancontroller.NewLinearFanController(fancontroller.Config{}, clk)
+	require.Nil(t, err)
+
+	return &computeBladeAgent{
+		blade:            blade,
+		edgeLed:          ledengine.NewCompositor(ledengine.New(blade, hal.LedEdge), nil),
+		topLed:           ledengine.NewCompositor(ledengine.New(blade, hal.LedTop), nil),
+		fanController:    fanController,
+		state:            agent.NewComputeBladeState(),
+		identifyTracker:  agent.NewIdentifyTracker(nil),
+		fanHealthMonitor: agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{}, nil),
+		clock:            clk,
+	}
+}
+
+func TestHandleEvent_CriticalRestoresFanSpeedLease(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &manualClock{now: start}
+	a := newCriticalOverrideAgent(t, clk)
+	ctx := context.Background()
+
+	_, err := a.SetFanSpeed(ctx, &bladeapiv1alpha1.SetFanSpeedRequest{Percent: 40, DurationSeconds: 60, Owner: "user@host"})
+	require.NoError(t, err)
+
+	require.NoError(t, a.handleEvent(ctx, events.CriticalEvent))
+	override := a.fanController.ActiveOverride()
+	require.NotNil(t, override)
+	assert.Equal(t, "critical-mode", override.Owner)
+	assert.EqualValues(t, 100, override.Percent)
+
+	// The lease is still valid once critical mode is cleared, and keeps its expiry
+	clk.now = start.Add(30 * time.Second)
+	require.NoError(t, a.handleEvent(ctx, events.CriticalResetEvent))
+	override = a.fanController.ActiveOverride()
+	require.NotNil(t, override)
+	assert.Equal(t, "user@host", override.Owner)
+	assert.EqualValues(t, 40, override.Percent)
+	assert.Equal(t, start.Add(time.Minute), override.ExpiresAt)
+}
+
+func TestHandleEvent_CriticalDropsExpiredFanSpeedLease(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &manualClock{now: start}
+	a := newCriticalOverrideAgent(t, clk)
+	ctx := context.Background()
+
+	_, err := a.SetFanSpeed(ctx, &bladeapiv1alpha1.SetFanSpeedRequest{Percent: 40, DurationSeconds: 60, Owner: "user@host"})
+	require.NoError(t, err)
+	require.NoError(t, a.handleEvent(ctx, events.CriticalEvent))
+
+	// The lease expired during critical mode, the fan speed is controlled automatically again
+	clk.now = start.Add(2 * time.Minute)
+	require.NoError(t, a.handleEvent(ctx, events.CriticalResetEvent))
+	assert.Nil(t, a.fanController.ActiveOverride())
+	assert.True(t, a.fanController.IsAutomaticSpeed())
+}
+
+func TestHandleEvent_CriticalFanSpeedAuto(t *testing.T) {
+	t.Parallel()
+
+	clk := &manualClock{now: time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)}
+	a := newCriticalOverrideAgent(t, clk)
+	ctx := context.Background()
+
+	_, err := a.SetFanSpeed(ctx, &bladeapiv1alpha1.SetFanSpeedRequest{Percent: 40, Owner: "user@host"})
+	require.NoError(t, err)
+	require.NoError(t, a.handleEvent(ctx, events.CriticalEvent))
+
+	// The fan keeps running at 100% until critical mode is cleared, but doesn't return to the override afterwards
+	_, err = a.SetFanSpeedAuto(ctx, nil)
+	require.NoError(t, err)
+	override := a.fanController.ActiveOverride()
+	require.NotNil(t, override)
+	assert.Equal(t, "critical-mode", override.Owner)
+
+	require.NoError(t, a.handleEvent(ctx, events.CriticalResetEvent))
+	assert.Nil(t, a.fanController.ActiveOverride())
+	assert.True(t, a.fanController.IsAutomaticSpeed())
+}
@@ -0,0 +1,158 @@
+package internal_agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// edgeButtonRequester is the requester of identify mode triggered by the edge button
+const edgeButtonRequester = "edge-button"
+
+// identifyRequest describes how identify mode is shown, for how long and who requested it
+type identifyRequest struct {
+	requester string
+	animation ledengine.Animation
+	// duration is the time after which identify mode is cleared, 0 keeps it until it is confirmed
+	duration time.Duration
+}
+
+// identifyMode holds the request applied by the next identify event
+type identifyMode struct {
+	mu sync.Mutex
+	// pending is applied by the next identify event, the default identify mode is shown if nil
+	pending *identifyRequest
+}
+
+// SetIdentify activates identify mode with the requested pattern, optionally clearing it after the requested duration
+func (a *computeBladeAgent) SetIdentify(ctx context.Context, req *bladeapiv1alpha1.SetIdentifyRequest) (*emptypb.Empty, error) {
+	request, err := a.identifyRequestFromProto(req)
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	if err := a.requestIdentify(ctx, request); err != nil {
+		return &emptypb.Empty{}, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// defaultIdentifyRequest returns the identify mode triggered without options, bursting in the identify LED color
+func (a *computeBladeAgent) defaultIdentifyRequest(requester string) identifyRequest {
+	return identifyRequest{
+		requester: requester,
+		animation: ledengine.NewBurstPattern(led.Color{}, a.config.IdentifyLedColor).Animation(),
+	}
+}
+
+// identifyRequestFromProto validates the request and converts it, unset options fall back to the default identify mode
+func (a *computeBladeAgent) identifyRequestFromProto(req *bladeapiv1alpha1.SetIdentifyRequest) (identifyRequest, humane.Error) {
+	if req.GetDurationSeconds() < 0 {
+		return identifyRequest{}, humane.New("identify duration must not be negative",
+			"omit the duration to keep identify mode until it is confirmed",
+		)
+	}
+
+	request := a.defaultIdentifyRequest(req.GetRequester())
+	request.duration = time.Duration(req.GetDurationSeconds()) * time.Second
+
+	if req.Pattern == nil && req.Color == nil {
+		return request, nil
+	}
+
+	pattern := bladeapiv1alpha1.LedPattern_LED_PATTERN_BURST
+	if req.Pattern != nil {
+		pattern = req.GetPattern()
+	}
+	if pattern == bladeapiv1alpha1.LedPattern_LED_PATTERN_KEYFRAMES {
+		return identifyRequest{}, humane.New("identify mode doesn't support keyframes",
+			"use one of static, slow_blink, burst or breathing",
+		)
+	}
+
+	color := a.config.IdentifyLedColor
+	if req.Color != nil {
+		var err humane.Error
+		if color, err = ledColorFromProto(req.GetColor()); err != nil {
+			return identifyRequest{}, err
+		}
+	}
+
+	animation, err := ledAnimation(pattern, led.Color{}, color, nil)
+	if err != nil {
+		return identifyRequest{}, err
+	}
+	request.animation = animation
+
+	return request, nil
+}
+
+// requestIdentify sends an identify event applying the request to the event handler
+func (a *computeBladeAgent) requestIdentify(ctx context.Context, request identifyRequest) error {
+	a.identify.mu.Lock()
+	a.identify.pending = &request
+	a.identify.mu.Unlock()
+
+	select {
+	case a.eventChan <- events.IdentifyEvent:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleIdentifyActive shows the pending identify request on the edge LED, or bursts in the identify LED color
+// if there is none. Identify mode is cleared by an identify confirm event once the requested duration expired.
+func (a *computeBladeAgent) handleIdentifyActive(ctx context.Context) error {
+	a.identify.mu.Lock()
+	request := a.defaultIdentifyRequest("")
+	if a.identify.pending != nil {
+		request = *a.identify.pending
+		a.identify.pending = nil
+	}
+	a.identify.mu.Unlock()
+
+	generation := a.identifyTracker.Activate(request.requester, request.duration)
+
+	log.FromContext(ctx).Info("Identify active",
+		zap.String("requester", request.requester),
+		zap.Duration("duration", request.duration),
+	)
+
+	if request.duration > 0 {
+		go a.expireIdentify(ctx, generation, request.duration)
+	}
+	return a.edgeLed.Set(ledengine.LayerIdentify, request.animation)
+}
+
+// expireIdentify confirms identify mode after the duration, unless it was changed in the meantime.
+// Unlike other events raised by the agent, the confirm event is not repeated, so it waits for room in the event channel.
+func (a *computeBladeAgent) expireIdentify(ctx context.Context, generation uint64, duration time.Duration) {
+	if !a.identifyTracker.WaitForExpiry(ctx, generation, duration) {
+		return
+	}
+
+	log.FromContext(ctx).Info("Identify expired", zap.Duration("duration", duration))
+	select {
+	case a.eventChan <- events.IdentifyConfirmEvent:
+	case <-ctx.Done():
+	}
+}
+
+// handleIdentifyConfirm handles the confirmation of an identify event by removing the identify LED layer,
+// revealing whatever the edge LED showed before.
+func (a *computeBladeAgent) handleIdentifyConfirm(ctx context.Context) error {
+	a.identifyTracker.Clear()
+
+	log.FromContext(ctx).Info("Identify confirmed/cleared")
+	return a.edgeLed.Clear(ledengine.LayerIdentify)
+}
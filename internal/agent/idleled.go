@@ -0,0 +1,67 @@
+package internal_agent
+
+import (
+	"context"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+)
+
+// defaultIdleLedGradient is green, yellow and red at the brightness of the default idle LED color
+var defaultIdleLedGradient = ledengine.Gradient{{Green: 16}, {Red: 16, Green: 16}, {Red: 16}}
+
+// defaultIdleLedGradientSpan is the temperature range below the critical threshold covered by the gradient,
+// if neither a minimum temperature nor a fan curve is configured
+const defaultIdleLedGradientSpan = 20.0
+
+// idleLedColor returns the color of the idle edge LED at the given SoC temperature
+func (a *computeBladeAgent) idleLedColor(temp float64) led.Color {
+	config := a.config.IdleLedGradient
+	if !config.Enabled {
+		return a.config.IdleLedColor
+	}
+
+	gradient := ledengine.Gradient(config.Colors)
+	if len(gradient) == 0 {
+		gradient = defaultIdleLedGradient
+	}
+
+	// The gradient spans from the temperature the fan starts ramping up to the critical temperature threshold
+	maxTemp := float64(a.config.CriticalTemperatureThreshold)
+	minTemp := config.MinTemperature
+	if minTemp == 0 {
+		minTemp = maxTemp - defaultIdleLedGradientSpan
+		if steps := a.fanController.Steps(); len(steps) > 0 {
+			minTemp = steps[0].Temperature
+		}
+	}
+	if maxTemp <= minTemp {
+		if temp < maxTemp {
+			return gradient.At(0)
+		}
+		return gradient.At(1)
+	}
+
+	return gradient.At((temp - minTemp) / (maxTemp - minTemp))
+}
+
+// updateIdleLed shows the color of the temperature gradient on the idle layer of the edge LED, if enabled.
+// The LED is only updated when the color changes.
+func (a *computeBladeAgent) updateIdleLed(ctx context.Context, temp float64) {
+	if !a.config.IdleLedGradient.Enabled {
+		return
+	}
+
+	color := a.idleLedColor(temp)
+	if a.idleLedShown && color == a.idleLedShownColor {
+		return
+	}
+
+	if err := a.edgeLed.SetPattern(ledengine.LayerIdle, ledengine.NewStaticPattern(color)); err != nil {
+		log.FromContext(ctx).WithError(err).Error("Failed to update idle LED")
+		return
+	}
+	a.idleLedShown = true
+	a.idleLedShownColor = color
+}
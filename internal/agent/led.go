@@ -0,0 +1,172 @@
+package internal_agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// userLedBreathingPeriod is the duration of a breath of the breathing pattern
+	userLedBreathingPeriod = 4 * time.Second
+
+	// maxLedKeyframes limits the keyframes of a user defined pattern
+	maxLedKeyframes = 64
+	// minLedKeyframeDuration is the shortest keyframe of a user defined pattern, one frame at the maximum frame rate
+	minLedKeyframeDuration = 20 * time.Millisecond
+)
+
+// SetLed shows a user defined pattern on the user layer of an LED, which yields to identify and critical mode
+func (a *computeBladeAgent) SetLed(ctx context.Context, req *bladeapiv1alpha1.SetLedRequest) (*emptypb.Empty, error) {
+	compositor, err := a.ledCompositor(req.GetLed())
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+	if req.GetTtlSeconds() < 0 {
+		return &emptypb.Empty{}, humane.New("LED pattern ttl must not be negative",
+			"omit the ttl to keep the pattern until it is cleared",
+		)
+	}
+
+	animation, err := userLedAnimation(req)
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+	if err := compositor.SetFor(ledengine.LayerUser, animation, ttl); err != nil {
+		return &emptypb.Empty{}, humane.Wrap(err, "failed to set LED pattern")
+	}
+	log.FromContext(ctx).Info("LED pattern set",
+		zap.String("led", req.GetLed().String()),
+		zap.String("pattern", req.GetPattern().String()),
+		zap.Duration("ttl", ttl),
+	)
+
+	return &emptypb.Empty{}, nil
+}
+
+// ClearLed removes the user layer of an LED, revealing what the LED showed before
+func (a *computeBladeAgent) ClearLed(ctx context.Context, req *bladeapiv1alpha1.ClearLedRequest) (*emptypb.Empty, error) {
+	compositor, err := a.ledCompositor(req.GetLed())
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	if err := compositor.Clear(ledengine.LayerUser); err != nil {
+		return &emptypb.Empty{}, humane.Wrap(err, "failed to clear LED pattern")
+	}
+	log.FromContext(ctx).Info("LED pattern cleared", zap.String("led", req.GetLed().String()))
+
+	return &emptypb.Empty{}, nil
+}
+
+// SetLedBrightness scales all LED colors with the requested brightness, until the schedule changes the brightness
+func (a *computeBladeAgent) SetLedBrightness(ctx context.Context, req *bladeapiv1alpha1.SetLedBrightnessRequest) (*emptypb.Empty, error) {
+	percent, err := percentFromProto("LED brightness", req.GetPercent())
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+	if err := a.ledCorrection.SetBrightness(percent); err != nil {
+		return &emptypb.Empty{}, err
+	}
+	log.FromContext(ctx).Info("LED brightness set", zap.Uint32("percent", req.GetPercent()))
+
+	return &emptypb.Empty{}, nil
+}
+
+// ledCompositor returns the compositor of the LED
+func (a *computeBladeAgent) ledCompositor(idx bladeapiv1alpha1.LedIndex) (*ledengine.Compositor, humane.Error) {
+	switch idx {
+	case bladeapiv1alpha1.LedIndex_LED_TOP:
+		return a.topLed, nil
+	case bladeapiv1alpha1.LedIndex_LED_EDGE:
+		return a.edgeLed, nil
+	default:
+		return nil, humane.New(fmt.Sprintf("unknown LED %d", idx), "use the top or the edge LED")
+	}
+}
+
+// userLedAnimation converts the pattern of the request into an animation
+func userLedAnimation(req *bladeapiv1alpha1.SetLedRequest) (ledengine.Animation, humane.Error) {
+	color, err := ledColorFromProto(req.GetColor())
+	if err != nil {
+		return ledengine.Animation{}, err
+	}
+	baseColor, err := ledColorFromProto(req.GetBaseColor())
+	if err != nil {
+		return ledengine.Animation{}, err
+	}
+
+	return ledAnimation(req.GetPattern(), baseColor, color, req.GetKeyframes())
+}
+
+// ledAnimation converts the pattern into an animation, blinking or breathing between baseColor and color
+func ledAnimation(pattern bladeapiv1alpha1.LedPattern, baseColor led.Color, color led.Color, keyframes []*bladeapiv1alpha1.LedKeyframe) (ledengine.Animation, humane.Error) {
+	var animation ledengine.Animation
+	switch pattern {
+	case bladeapiv1alpha1.LedPattern_LED_PATTERN_STATIC:
+		animation = ledengine.NewStaticAnimation(color)
+	case bladeapiv1alpha1.LedPattern_LED_PATTERN_SLOW_BLINK:
+		animation = ledengine.NewSlowBlinkPattern(baseColor, color).Animation()
+	case bladeapiv1alpha1.LedPattern_LED_PATTERN_BURST:
+		animation = ledengine.NewBurstPattern(baseColor, color).Animation()
+	case bladeapiv1alpha1.LedPattern_LED_PATTERN_BREATHING:
+		animation = ledengine.NewBreathingAnimation(baseColor, color, userLedBreathingPeriod)
+	case bladeapiv1alpha1.LedPattern_LED_PATTERN_KEYFRAMES:
+		if len(keyframes) > maxLedKeyframes {
+			return ledengine.Animation{}, humane.New(fmt.Sprintf("LED pattern has %d keyframes, at most %d are supported", len(keyframes), maxLedKeyframes),
+				"simplify the pattern",
+			)
+		}
+		for _, keyframe := range keyframes {
+			keyframeColor, err := ledColorFromProto(keyframe.GetColor())
+			if err != nil {
+				return ledengine.Animation{}, err
+			}
+			if duration := time.Duration(keyframe.GetDurationMs()) * time.Millisecond; duration < minLedKeyframeDuration {
+				return ledengine.Animation{}, humane.New(fmt.Sprintf("LED keyframe duration of %dms is too short", keyframe.GetDurationMs()),
+					fmt.Sprintf("use keyframes of at least %s", minLedKeyframeDuration),
+				)
+			}
+			animation.Keyframes = append(animation.Keyframes, ledengine.Keyframe{
+				Color:         keyframeColor,
+				Duration:      time.Duration(keyframe.GetDurationMs()) * time.Millisecond,
+				Interpolation: ledengine.Interpolation(keyframe.GetInterpolation()),
+			})
+		}
+	default:
+		return ledengine.Animation{}, humane.New(fmt.Sprintf("unknown LED pattern %d", pattern),
+			"use one of static, slow_blink, burst, breathing or keyframes",
+		)
+	}
+
+	if err := animation.Validate(); err != nil {
+		return ledengine.Animation{}, humane.Wrap(err, "invalid LED pattern",
+			"ensure there is at least one keyframe, no duration is negative and the keyframes take some time",
+		)
+	}
+	return animation, nil
+}
+
+// ledColorFromProto converts the color, unset colors are off
+func ledColorFromProto(color *bladeapiv1alpha1.LedColor) (led.Color, humane.Error) {
+	if color.GetRed() > 255 || color.GetGreen() > 255 || color.GetBlue() > 255 {
+		return led.Color{}, humane.New("LED color components must be between 0 and 255",
+			fmt.Sprintf("got red=%d, green=%d, blue=%d", color.GetRed(), color.GetGreen(), color.GetBlue()),
+		)
+	}
+	return led.Color{
+		Red:   uint8(color.GetRed()),
+		Green: uint8(color.GetGreen()),
+		Blue:  uint8(color.GetBlue()),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package internal_agent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/schedule"
+	"go.uber.org/zap"
+)
+
+// scheduleInterval is how often the schedule is evaluated
+const scheduleInterval = 15 * time.Second
+
+// runSchedule periodically evaluates the schedule and applies transitions once their boundary has passed.
+// The current schedule state is applied right away on startup.
+func (a *computeBladeAgent) runSchedule(ctx context.Context, cancel context.CancelCauseFunc) {
+	if len(a.config.Schedule) == 0 {
+		return
+	}
+
+	log.FromContext(ctx).Info("Starting schedule")
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		if transition, ok := a.schedule.Due(); ok {
+			a.applyScheduleTransition(ctx, transition)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
+				log.FromContext(ctx).WithError(err).Error("Schedule failed")
+				cancel(err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyScheduleTransition switches the fan profile, LED brightness and/or stealth mode as defined by the transition.
+// While the blade is in critical mode, the stealth mode is only recorded and applied once critical mode is cleared.
+func (a *computeBladeAgent) applyScheduleTransition(ctx context.Context, transition schedule.Transition) {
+	logger := log.FromContext(ctx).With(zap.Time("boundary", transition.Time))
+
+	if transition.FanProfile != "" {
+		if err := a.fanProfiles.Activate(transition.FanProfile); err != nil {
+			logger.WithError(err).Error("Failed to activate scheduled fan profile")
+		} else {
+			logger.Info("Scheduled fan profile activated", zap.String("profile", transition.FanProfile))
+		}
+	}
+
+	if transition.LedBrightness != nil {
+		if err := a.ledCorrection.SetBrightness(*transition.LedBrightness); err != nil {
+			logger.WithError(err).Error("Failed to set scheduled LED brightness")
+		} else {
+			logger.Info("Scheduled LED brightness applied", zap.Uint8("percent", *transition.LedBrightness))
+		}
+	}
+
+	if transition.StealthMode != nil {
+		a.stealthMode.Store(*transition.StealthMode)
+		if a.state.CriticalActive() {
+			logger.Info("Blade in critical state, deferring scheduled stealth mode")
+			return
+		}
+
+		if err := a.blade.SetStealthMode(*transition.StealthMode); err != nil {
+			logger.WithError(err).Error("Failed to set scheduled stealth mode")
+		} else {
+			logger.Info("Scheduled stealth mode applied", zap.Bool("stealth_mode", *transition.StealthMode))
+		}
+	}
+}
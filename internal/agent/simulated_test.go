@@ -0,0 +1,97 @@
+package internal_agent
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock only advances when advanced by the test, its timers never fire
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) After(time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+func TestAgent_SimulatedOverheat(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &manualClock{now: start}
+
+	config := agent.ComputeBladeAgentConfig{
+		ComputeBladeHalOpts: hal.ComputeBladeHalOpts{SimulatedScenario: filepath.Join("testdata", "overheat.yaml")},
+		FanControllerConfig: fancontroller.Config{
+			Steps: []fancontroller.Step{
+				{Temperature: 40, Percent: 40},
+				{Temperature: 60, Percent: 80},
+			},
+			Calibration: fancontroller.CalibrationConfig{Path: filepath.Join(t.TempDir(), "fan-calibration.json")},
+		},
+		CriticalTemperatureThreshold: 60,
+		AutomaticCriticalMode:        true,
+	}
+
+	blade, err := hal.NewSimulatedHal(ctx, config.ComputeBladeHalOpts, clk)
+	require.NoError(t, err)
+	a, err := newComputeBladeAgent(ctx, config, agent.ComputeBladeAgentInfo{}, blade, clk)
+	require.NoError(t, err)
+
+	// advance plays the scenario up to the given time, running the fan controller and handling the raised events
+	// like the agent does every few seconds
+	advance := func(at time.Duration) {
+		clk.now = start.Add(at)
+		for range 2 {
+			a.updateFanSpeed(ctx)
+			for len(a.eventChan) > 0 {
+				require.NoError(t, a.handleEvent(ctx, <-a.eventChan))
+			}
+		}
+	}
+
+	// Idle blade, the fan follows the fan curve
+	advance(0)
+	status, err := a.GetStatus(ctx, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, status.GetTemperature())
+	assert.EqualValues(t, 2000, status.GetFanRpm())
+	assert.False(t, status.GetCriticalActive())
+
+	// Above the critical temperature, the fan runs at full speed
+	advance(8 * time.Minute)
+	status, err = a.GetStatus(ctx, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 72, status.GetTemperature())
+	assert.EqualValues(t, 5000, status.GetFanRpm())
+	assert.True(t, status.GetCriticalActive())
+
+	// Still above the reset temperature, critical mode is kept
+	advance(15 * time.Minute)
+	status, err = a.GetStatus(ctx, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 60, status.GetTemperature())
+	assert.EqualValues(t, 5000, status.GetFanRpm())
+	assert.True(t, status.GetCriticalActive())
+
+	// Cooled down, the fan follows the fan curve again
+	advance(19 * time.Minute)
+	status, err = a.GetStatus(ctx, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 44, status.GetTemperature())
+	assert.EqualValues(t, 2400, status.GetFanRpm())
+	assert.False(t, status.GetCriticalActive())
+}
@@ -0,0 +1,52 @@
+package internal_agent
+
+import (
+	"context"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	// inputTemperature is a prometheus gauge exposing the temperature of the additional fan controller inputs
+	inputTemperature = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "computeblade_agent",
+		Name:      "input_temperature",
+		Help:      "Temperature of the additional fan controller inputs in °C",
+	}, []string{"input"})
+
+	// fanTargetInputPercent is a prometheus gauge exposing the fan speed requested by each additional fan controller input
+	fanTargetInputPercent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "computeblade_agent",
+		Name:      "fan_target_input_percent",
+		Help:      "Fan speed in percent as requested by the fan curve of the additional fan controller inputs",
+	}, []string{"input"})
+)
+
+// readInputTemperature reads the temperature of an additional fan controller input.
+// If the temperature cannot be read, a high value is returned to trigger the maximum speed defined by the input's fan curve.
+func (a *computeBladeAgent) readInputTemperature(ctx context.Context, input *fancontroller.Input) float64 {
+	var temp float64
+	var err error
+
+	switch input.Source() {
+	case fancontroller.TemperatureSourceSoC:
+		temp, err = a.blade.GetTemperature()
+	case fancontroller.TemperatureSourceAirFlow:
+		temp, err = a.blade.GetAirFlowTemperature()
+	case fancontroller.TemperatureSourceSysfs:
+		temp, err = hal.ReadSysfsTemperature(input.Path())
+	}
+
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("Failed to get input temperature", zap.String("input", input.Name()))
+		temp = 100
+	}
+
+	inputTemperature.WithLabelValues(input.Name()).Set(temp)
+	return temp
+}
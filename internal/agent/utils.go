@@ -1,8 +1,11 @@
 package internal_agent
 
 import (
+	"fmt"
+
 	bladeapiv1alpha1 "github.com/compute-blade-community/compute-blade-agent/api/bladeapi/v1alpha1"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/sierrasoftworks/humane-errors-go"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -23,3 +26,14 @@ func fromProto(event bladeapiv1alpha1.Event) (events.Event, error) {
 		return events.NoopEvent, status.Errorf(codes.InvalidArgument, "invalid event type")
 	}
 }
+
+// percentFromProto validates a percentage received via the API and converts it, so values beyond uint8 are rejected
+// instead of wrapping around. name describes the value in the error.
+func percentFromProto[T int64 | uint32](name string, percent T) (uint8, humane.Error) {
+	if percent < 0 || percent > 100 {
+		return 0, humane.New(fmt.Sprintf("%s must be between 0 and 100 percent, got %d", name, percent),
+			fmt.Sprintf("set the %s to a value between 0 and 100", name),
+		)
+	}
+	return uint8(percent), nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/schedule"
 )
 
 type LogConfiguration struct {
@@ -32,6 +33,9 @@ type ComputeBladeAgentConfig struct {
 	// IdleLedColor is the color of the edge LED when the blade is idle mode
 	IdleLedColor led.Color `mapstructure:"idle_led_color"`
 
+	// IdleLedGradient colors the idle edge LED by the SoC temperature instead of using IdleLedColor
+	IdleLedGradient IdleLedGradientConfig `mapstructure:"idle_led_gradient"`
+
 	// IdentifyLedColor is the color of the edge LED when the blade is in identify mode
 	IdentifyLedColor led.Color `mapstructure:"identify_led_color"`
 
@@ -39,19 +43,63 @@ type ComputeBladeAgentConfig struct {
 	// In the circumstance when >1 blades are in critical mode, the identify function can be used to find the right blade
 	CriticalLedColor led.Color `mapstructure:"critical_led_color"`
 
+	// FaultLedColor is the color of the top LED flashing the blink codes of active faults. Defaults to amber.
+	FaultLedColor led.Color `mapstructure:"fault_led_color"`
+
+	// ExpectSmartFanUnit raises a fault if no smart fan unit is detected
+	ExpectSmartFanUnit bool `mapstructure:"expect_smart_fan_unit"`
+
 	// StealthModeEnabled indicates whether stealth mode is enabled
 	StealthModeEnabled bool `mapstructure:"stealth_mode"`
 
 	// Critical temperature of the compute blade (used to trigger critical mode)
 	CriticalTemperatureThreshold uint `mapstructure:"critical_temperature_threshold"`
 
+	// AutomaticCriticalMode enables entering and leaving critical mode based on CriticalTemperatureThreshold.
+	// Enabled by default, if disabled critical mode is only raised manually or by a failed fan.
+	AutomaticCriticalMode bool `mapstructure:"automatic_critical_mode"`
+
+	// CriticalResetTemperatureThreshold is the temperature the blade has to cool down below before critical mode is cleared.
+	// Defaults to 5°C below CriticalTemperatureThreshold.
+	CriticalResetTemperatureThreshold uint `mapstructure:"critical_reset_temperature_threshold"`
+
+	// CriticalTemperatureDwell is how long the temperature has to stay above CriticalTemperatureThreshold before critical mode is triggered
+	CriticalTemperatureDwell time.Duration `mapstructure:"critical_temperature_dwell"`
+
+	// FanHealth configures the detection of stalled and failed fans, which drives the blade into critical mode
+	FanHealth FanHealthMonitorConfig `mapstructure:"fan_health"`
+
 	// FanSpeed allows to set a fixed fan speed (in percent)
 	FanSpeed *fancontroller.FanOverrideOpts `mapstructure:"fan_speed"`
 
 	// FanControllerConfig is the configuration of the fan controller
 	FanControllerConfig fancontroller.Config `mapstructure:"fan_controller"`
 
+	// FanProfiles are named fan curves that can be switched at runtime
+	FanProfiles map[string]fancontroller.Profile `mapstructure:"fan_profiles"`
+
+	// FanProfile is the fan profile activated on startup. If empty, fan_controller.steps is used.
+	FanProfile string `mapstructure:"fan_profile"`
+
+	// Schedule switches the fan profile and/or stealth mode at given times of the day.
+	// Manual changes take priority until the next schedule boundary.
+	Schedule []schedule.Entry `mapstructure:"schedule"`
+
 	ComputeBladeHalOpts hal.ComputeBladeHalOpts `mapstructure:"hal"`
+
+	// ConfigFile is the configuration file the agent was started with, empty if there is none
+	ConfigFile string `mapstructure:"-"`
+}
+
+// IdleLedGradientConfig configures the temperature gradient shown on the idle edge LED
+type IdleLedGradientConfig struct {
+	// Enabled colors the idle edge LED by the SoC temperature
+	Enabled bool `mapstructure:"enabled"`
+	// Colors are evenly spaced from MinTemperature to the critical temperature threshold.
+	// Defaults to green, yellow and red.
+	Colors []led.Color `mapstructure:"colors"`
+	// MinTemperature is the temperature shown with the first color. Defaults to the lowest temperature of the fan curve.
+	MinTemperature float64 `mapstructure:"min_temperature"`
 }
 
 // ComputeBladeAgentInfo represents metadata information about a compute blade agent, including version, commit, and build time.
@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"gopkg.in/yaml.v3"
+)
+
+// configEdit replaces the lines start to end (1-based, inclusive) of a configuration file.
+// An end before start inserts the lines before start.
+type configEdit struct {
+	start int
+	end   int
+	lines []string
+}
+
+// PersistFanCurve replaces fan_controller.steps in the configuration file. Only the lines of the fan curve are
+// rewritten, all other settings, comments and the formatting are kept. fan_profile is cleared, as a fan profile
+// would replace the persisted fan curve on startup. The file is replaced atomically.
+func PersistFanCurve(path string, steps []fancontroller.Step) humane.Error {
+	if path == "" {
+		return humane.New("cannot persist fan curve without a configuration file",
+			"start the agent with a configuration file in /etc/compute-blade-agent to persist the fan curve",
+		)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return humane.Wrap(err, "failed to read configuration file", fmt.Sprintf("ensure the agent can read %s", path))
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return humane.Wrap(err, "failed to parse configuration file", fmt.Sprintf("ensure %s is valid YAML", path))
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		lines[len(lines)-1] += "\n"
+	}
+	appendAt := len(lines) + 1
+
+	var root *yaml.Node
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+	if root != nil && (root.Kind != yaml.MappingNode || root.Style&yaml.FlowStyle != 0) {
+		return humane.New("failed to persist fan curve, the configuration is not a YAML block mapping",
+			fmt.Sprintf("update fan_controller.steps in %s manually", path),
+		)
+	}
+
+	var edits []configEdit
+	fanControllerKey, fanController := mappingEntry(root, "fan_controller")
+	switch {
+	case fanController == nil:
+		edits = append(edits, configEdit{
+			start: appendAt,
+			end:   appendAt - 1,
+			lines: append([]string{"fan_controller:\n"}, renderSteps("  ", steps)...),
+		})
+
+	case fanController.Kind != yaml.MappingNode || fanController.Style&yaml.FlowStyle != 0:
+		return humane.New("failed to persist fan curve, fan_controller is not a YAML block mapping",
+			fmt.Sprintf("update fan_controller.steps in %s manually", path),
+		)
+
+	default:
+		stepsKey, stepsValue := mappingEntry(fanController, "steps")
+		if stepsKey == nil {
+			indent := strings.Repeat(" ", fanControllerKey.Column+1)
+			if len(fanController.Content) > 0 {
+				indent = strings.Repeat(" ", fanController.Content[0].Column-1)
+			}
+			end := lastLine(fanController)
+			edits = append(edits, configEdit{start: end + 1, end: end, lines: renderSteps(indent, steps)})
+			break
+		}
+
+		end := lastLine(stepsValue)
+		if stepsValue.Style&yaml.FlowStyle != 0 && end > stepsKey.Line {
+			return humane.New("failed to persist fan curve, fan_controller.steps spans multiple lines in flow style",
+				fmt.Sprintf("write fan_controller.steps in %s in block style or on a single line", path),
+			)
+		}
+		edits = append(edits, configEdit{
+			start: stepsKey.Line,
+			end:   end,
+			lines: renderSteps(strings.Repeat(" ", stepsKey.Column-1), steps),
+		})
+	}
+
+	if fanProfileKey, fanProfile := mappingEntry(root, "fan_profile"); fanProfile != nil && fanProfile.Value != "" {
+		if lastLine(fanProfile) != fanProfileKey.Line {
+			return humane.New("failed to persist fan curve, fan_profile spans multiple lines",
+				fmt.Sprintf("clear fan_profile in %s manually", path),
+			)
+		}
+		line := strings.Repeat(" ", fanProfileKey.Column-1) + `fan_profile: ""`
+		if fanProfile.LineComment != "" {
+			line += " " + fanProfile.LineComment
+		}
+		edits = append(edits, configEdit{start: fanProfileKey.Line, end: fanProfileKey.Line, lines: []string{line + "\n"}})
+	}
+
+	// Apply the edits from the bottom up, so the line numbers of the remaining edits stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, edit := range edits {
+		updated := append([]string{}, lines[:edit.start-1]...)
+		updated = append(updated, edit.lines...)
+		lines = append(updated, lines[edit.end:]...)
+	}
+
+	if err := util.WriteFileAtomic(path, []byte(strings.Join(lines, ""))); err != nil {
+		return humane.Wrap(err, "failed to persist fan curve", fmt.Sprintf("ensure the agent can write to %s", path))
+	}
+
+	return nil
+}
+
+// renderSteps renders the fan curve as a block sequence, indented with indent
+func renderSteps(indent string, steps []fancontroller.Step) []string {
+	lines := []string{indent + "steps:\n"}
+	for _, step := range steps {
+		lines = append(lines,
+			fmt.Sprintf("%s  - temperature: %s\n", indent, strconv.FormatFloat(step.Temperature, 'f', -1, 64)),
+			fmt.Sprintf("%s    percent: %d\n", indent, step.Percent),
+		)
+	}
+	return lines
+}
+
+// mappingEntry returns the key and value node of key in the mapping node, or nil if the key is missing
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if mapping == nil {
+		return nil, nil
+	}
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value == key {
+			return mapping.Content[idx], mapping.Content[idx+1]
+		}
+	}
+	return nil, nil
+}
+
+// lastLine returns the last line of the node and its children, excluding comments
+func lastLine(node *yaml.Node) int {
+	line := node.Line
+	if node.Kind == yaml.ScalarNode && (node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0) {
+		line += strings.Count(strings.TrimSuffix(node.Value, "\n"), "\n") + 1
+	}
+	for _, child := range node.Content {
+		line = max(line, lastLine(child))
+	}
+	return line
+}
@@ -0,0 +1,170 @@
+package agent_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFanCurve = []fancontroller.Step{
+	{Temperature: 40.5, Percent: 30},
+	{Temperature: 70, Percent: 100},
+}
+
+func TestPersistFanCurve(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name: "replaces steps and clears fan profile",
+			config: `# Agent configuration
+listen:
+    metrics: ":9666"
+
+fan_controller:
+    type: linear
+    # Fan curve
+    steps:
+        - temperature: 45
+          percent: 40
+        - temperature: 55
+          percent: 80
+
+    # Zero RPM mode
+    fan_off_temperature: 0
+
+fan_profile: quiet # selected on startup
+`,
+			expected: `# Agent configuration
+listen:
+    metrics: ":9666"
+
+fan_controller:
+    type: linear
+    # Fan curve
+    steps:
+      - temperature: 40.5
+        percent: 30
+      - temperature: 70
+        percent: 100
+
+    # Zero RPM mode
+    fan_off_temperature: 0
+
+fan_profile: "" # selected on startup
+`,
+		},
+		{
+			name: "replaces flow style steps",
+			config: `fan_controller:
+  steps: [{ temperature: 45, percent: 40 }]
+  hysteresis: 2
+`,
+			expected: `fan_controller:
+  steps:
+    - temperature: 40.5
+      percent: 30
+    - temperature: 70
+      percent: 100
+  hysteresis: 2
+`,
+		},
+		{
+			name: "adds missing steps",
+			config: `fan_controller:
+  hysteresis: 2
+listen:
+  metrics: ":9666"`,
+			expected: `fan_controller:
+  hysteresis: 2
+  steps:
+    - temperature: 40.5
+      percent: 30
+    - temperature: 70
+      percent: 100
+listen:
+  metrics: ":9666"
+`,
+		},
+		{
+			name: "adds missing fan controller",
+			config: `listen:
+  metrics: ":9666"
+`,
+			expected: `listen:
+  metrics: ":9666"
+fan_controller:
+  steps:
+    - temperature: 40.5
+      percent: 30
+    - temperature: 70
+      percent: 100
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o600))
+
+			err := agent.PersistFanCurve(path, testFanCurve)
+			require.Nil(t, err)
+
+			data, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			assert.Equal(t, tc.expected, string(data))
+
+			info, statErr := os.Stat(path)
+			require.NoError(t, statErr)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+			entries, dirErr := os.ReadDir(filepath.Dir(path))
+			require.NoError(t, dirErr)
+			assert.Len(t, entries, 1, "temporary file left behind")
+		})
+	}
+}
+
+func TestPersistFanCurve_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config string
+	}{
+		{name: "invalid yaml", config: "fan_controller: [\n"},
+		{name: "flow style fan controller", config: "fan_controller: { steps: [] }\n"},
+		{name: "multi-line flow style steps", config: "fan_controller:\n  steps: [\n    { temperature: 45, percent: 40 }\n  ]\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o644))
+
+			err := agent.PersistFanCurve(path, testFanCurve)
+			assert.NotNil(t, err)
+
+			data, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			assert.Equal(t, tc.config, string(data), "configuration must be left untouched")
+		})
+	}
+
+	assert.NotNil(t, agent.PersistFanCurve("", testFanCurve))
+	assert.NotNil(t, agent.PersistFanCurve(filepath.Join(t.TempDir(), "missing.yaml"), testFanCurve))
+}
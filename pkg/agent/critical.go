@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+)
+
+// defaultCriticalResetHysteresis is the distance to the critical threshold used when no explicit reset threshold is configured
+const defaultCriticalResetHysteresis = 5
+
+// CriticalTemperatureMonitorConfig configures when the CriticalTemperatureMonitor raises and clears critical mode
+type CriticalTemperatureMonitorConfig struct {
+	// Threshold is the temperature at (or above) which critical mode is raised. A threshold of 0 disables the monitor.
+	Threshold float64
+	// ResetThreshold is the temperature the SoC has to drop below before critical mode is cleared again.
+	// Defaults to Threshold - 5 if unset or not below Threshold.
+	ResetThreshold float64
+	// Dwell is the duration the temperature has to stay at or above Threshold before critical mode is raised.
+	Dwell time.Duration
+}
+
+// CriticalTemperatureMonitor watches temperature samples and decides when to raise or clear critical mode.
+// It raises and clears the temperature reason of critical mode only, so critical mode raised manually or by a failed
+// fan is left untouched.
+type CriticalTemperatureMonitor struct {
+	mu     sync.Mutex
+	config CriticalTemperatureMonitorConfig
+	clock  util.Clock
+
+	// aboveSince is the time the temperature first crossed the threshold, zero if it is below the threshold
+	aboveSince time.Time
+	// raised indicates whether the monitor has raised critical mode
+	raised bool
+}
+
+// NewCriticalTemperatureMonitor creates a new CriticalTemperatureMonitor. If clock is nil, the real clock is used.
+func NewCriticalTemperatureMonitor(config CriticalTemperatureMonitorConfig, clock util.Clock) *CriticalTemperatureMonitor {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	if config.ResetThreshold <= 0 || config.ResetThreshold >= config.Threshold {
+		config.ResetThreshold = config.Threshold - defaultCriticalResetHysteresis
+	}
+
+	return &CriticalTemperatureMonitor{
+		config: config,
+		clock:  clock,
+	}
+}
+
+// Observe ingests a temperature sample together with whether critical mode is currently raised for the temperature
+// and returns the event that has to be emitted as a result. While the blade state differs from the monitor, the event is
+// returned again on every sample until the blade follows, e.g. if the event was dropped. events.NoopEvent is returned if
+// no state change is required.
+func (m *CriticalTemperatureMonitor) Observe(temperature float64, criticalActive bool) events.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.config.Threshold <= 0 {
+		return events.NoopEvent
+	}
+
+	if m.raised {
+		switch {
+		case temperature >= m.config.ResetThreshold && !criticalActive:
+			// Still too hot, but the blade is not in critical mode (yet), e.g. the event was dropped or critical mode
+			// was cleared by someone else (e.g. bladectl)
+			return events.CriticalTemperatureEvent
+		case temperature >= m.config.ResetThreshold:
+			return events.NoopEvent
+		case criticalActive:
+			return events.CriticalTemperatureResetEvent
+		default:
+			// Cooled down and the blade has left critical mode, re-arm the monitor
+			m.reset()
+			return events.NoopEvent
+		}
+	}
+
+	if temperature < m.config.Threshold {
+		m.aboveSince = time.Time{}
+		return events.NoopEvent
+	}
+
+	now := m.clock.Now()
+	if m.aboveSince.IsZero() {
+		m.aboveSince = now
+	}
+
+	if now.Sub(m.aboveSince) < m.config.Dwell || criticalActive {
+		return events.NoopEvent
+	}
+
+	m.raised = true
+	return events.CriticalTemperatureEvent
+}
+
+// reset re-arms the monitor after critical mode has been cleared
+func (m *CriticalTemperatureMonitor) reset() {
+	m.raised = false
+	m.aboveSince = time.Time{}
+}
@@ -0,0 +1,113 @@
+package agent_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCriticalTemperatureMonitor_Disabled(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{}, nil)
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(120, false))
+}
+
+func TestCriticalTemperatureMonitor_NoDwell(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{
+		Threshold:      60,
+		ResetThreshold: 50,
+	}, nil)
+
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(59, false))
+	assert.Equal(t, events.Event(events.CriticalTemperatureEvent), monitor.Observe(60, false))
+
+	// Hysteresis: no reset until we drop below the reset threshold
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(61, true))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(55, true))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(50, true))
+	assert.Equal(t, events.Event(events.CriticalTemperatureResetEvent), monitor.Observe(49.9, true))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(45, false))
+}
+
+func TestCriticalTemperatureMonitor_Dwell(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := util.MockClock{}
+	clk.On("Now").Once().Return(start)
+	clk.On("Now").Once().Return(start.Add(20 * time.Second))
+	clk.On("Now").Once().Return(start.Add(40 * time.Second))
+	clk.On("Now").Once().Return(start.Add(50 * time.Second))
+	clk.On("Now").Once().Return(start.Add(80 * time.Second))
+
+	monitor := agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{
+		Threshold: 60,
+		Dwell:     30 * time.Second,
+	}, &clk)
+
+	// Above threshold, but not long enough
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(62, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(63, false))
+
+	// Short dip below the threshold restarts the dwell timer
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(58, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(62, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(62, false))
+	assert.Equal(t, events.Event(events.CriticalTemperatureEvent), monitor.Observe(62, false))
+
+	// Default reset threshold is 5°C below the critical threshold
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(56, true))
+	assert.Equal(t, events.Event(events.CriticalTemperatureResetEvent), monitor.Observe(54, true))
+
+	clk.AssertExpectations(t)
+}
+
+func TestCriticalTemperatureMonitor_ExternalStateChanges(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{
+		Threshold:      60,
+		ResetThreshold: 50,
+	}, nil)
+
+	// Critical mode raised for the temperature is left to the monitor that raised it
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(65, true))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(40, true))
+
+	assert.Equal(t, events.Event(events.CriticalTemperatureEvent), monitor.Observe(65, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(65, true))
+
+	// Critical mode raised by the monitor but cleared manually is raised again while still too hot
+	assert.Equal(t, events.Event(events.CriticalTemperatureEvent), monitor.Observe(65, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(65, true))
+
+	// Critical mode cleared manually before cooling down is not reset again
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(40, false))
+}
+
+func TestCriticalTemperatureMonitor_DroppedEvents(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewCriticalTemperatureMonitor(agent.CriticalTemperatureMonitorConfig{
+		Threshold:      60,
+		ResetThreshold: 50,
+	}, nil)
+
+	// The first event is dropped, so the blade does not follow and the event is emitted again
+	assert.Equal(t, events.Event(events.CriticalTemperatureEvent), monitor.Observe(65, false))
+	assert.Equal(t, events.Event(events.CriticalTemperatureEvent), monitor.Observe(65, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(65, true))
+
+	// The same applies to the reset event
+	assert.Equal(t, events.Event(events.CriticalTemperatureResetEvent), monitor.Observe(45, true))
+	assert.Equal(t, events.Event(events.CriticalTemperatureResetEvent), monitor.Observe(45, true))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(45, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(45, false))
+}
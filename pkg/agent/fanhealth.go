@@ -0,0 +1,190 @@
+package agent
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+)
+
+const (
+	// defaultFanHealthMinPercent is the commanded fan speed from which the fan is expected to spin
+	defaultFanHealthMinPercent = 20
+	// defaultFanHealthStallRPM is the speed below which a fan counts as stalled
+	defaultFanHealthStallRPM = 200
+	// defaultFanHealthTolerance is the allowed relative deviation from the expected fan speed
+	defaultFanHealthTolerance = 0.5
+)
+
+// FanFailure is a failure of the fan detected by the FanHealthMonitor
+type FanFailure uint8
+
+const (
+	// FanFailureNone indicates a healthy fan
+	FanFailureNone FanFailure = iota
+	// FanFailureStall indicates that the fan stopped spinning although it is commanded to run
+	FanFailureStall
+	// FanFailureTachMissing indicates that no tachometer signal has been received since the fan was commanded to run
+	FanFailureTachMissing
+	// FanFailureRPMOutOfRange indicates that the fan speed deviates too much from the commanded fan speed
+	FanFailureRPMOutOfRange
+)
+
+func (f FanFailure) String() string {
+	switch f {
+	case FanFailureNone:
+		return "none"
+	case FanFailureStall:
+		return "stall"
+	case FanFailureTachMissing:
+		return "tach_missing"
+	case FanFailureRPMOutOfRange:
+		return "rpm_out_of_range"
+	default:
+		return "unknown"
+	}
+}
+
+// FanHealthMonitorConfig configures when the FanHealthMonitor considers the fan to be failed
+type FanHealthMonitorConfig struct {
+	// Enabled enables the fan health monitor
+	Enabled bool `mapstructure:"enabled"`
+	// MinPercent is the commanded fan speed from which the fan is expected to spin. Defaults to 20%.
+	// Below, the fan may legitimately stand still and no checks are performed.
+	MinPercent uint8 `mapstructure:"min_percent"`
+	// StallRPM is the fan speed below which the fan counts as stalled. Defaults to 200 RPM.
+	StallRPM float64 `mapstructure:"stall_rpm"`
+	// MaxRPM is the fan speed expected at 100%. The expected fan speed scales linearly with the commanded percent.
+	// 0 disables the out-of-range check.
+	MaxRPM float64 `mapstructure:"max_rpm"`
+	// Tolerance is the allowed relative deviation from the expected fan speed. Defaults to 0.5 (±50%).
+	Tolerance float64 `mapstructure:"tolerance"`
+	// Dwell is the duration a failure (or recovery) has to persist before it is reported
+	Dwell time.Duration `mapstructure:"dwell"`
+}
+
+// FanHealthMonitor compares the commanded fan speed with the measured fan speed and detects failed fans
+type FanHealthMonitor struct {
+	mu     sync.Mutex
+	config FanHealthMonitorConfig
+	clock  util.Clock
+
+	// failure is the reported failure
+	failure FanFailure
+	// candidate is the condition observed most recently, and candidateSince the time it was first observed
+	candidate      FanFailure
+	candidateSince time.Time
+	// tachSeen indicates whether the fan has been seen spinning at least once
+	tachSeen bool
+}
+
+// NewFanHealthMonitor creates a new FanHealthMonitor. If clock is nil, the real clock is used.
+func NewFanHealthMonitor(config FanHealthMonitorConfig, clock util.Clock) *FanHealthMonitor {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	if config.MinPercent == 0 {
+		config.MinPercent = defaultFanHealthMinPercent
+	}
+	if config.StallRPM <= 0 {
+		config.StallRPM = defaultFanHealthStallRPM
+	}
+	if config.Tolerance <= 0 {
+		config.Tolerance = defaultFanHealthTolerance
+	}
+
+	return &FanHealthMonitor{
+		config: config,
+		clock:  clock,
+	}
+}
+
+// Failure returns the currently reported fan failure
+func (m *FanHealthMonitor) Failure() FanFailure {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.failure
+}
+
+// Observe ingests the commanded fan speed together with the measured fan speed (or the error reading it) and whether
+// the blade is in fan failure mode, and returns the event that has to be emitted as a result. The event is derived from
+// the reported failure and the state of the blade, so it is returned again on every sample until the blade follows,
+// e.g. if the event was dropped. events.NoopEvent is returned if no state change is required.
+func (m *FanHealthMonitor) Observe(percent uint8, rpm float64, rpmErr error, failureActive bool) events.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.config.Enabled {
+		m.update(percent, rpm, rpmErr)
+	}
+
+	switch {
+	case m.failure != FanFailureNone && !failureActive:
+		return events.FanFailureEvent
+	case m.failure == FanFailureNone && failureActive:
+		return events.FanFailureResetEvent
+	default:
+		// A change of the failure kind keeps the blade in fan failure mode
+		return events.NoopEvent
+	}
+}
+
+// update reports a failure (or recovery) once it persisted for the dwell time
+func (m *FanHealthMonitor) update(percent uint8, rpm float64, rpmErr error) {
+	if rpmErr == nil && rpm >= m.config.StallRPM {
+		m.tachSeen = true
+	}
+
+	// The fan may stand still at low speeds, the state can't be judged
+	if percent < m.config.MinPercent {
+		m.candidate = m.failure
+		m.candidateSince = time.Time{}
+		return
+	}
+
+	current := m.classify(percent, rpm, rpmErr)
+	if current == m.failure {
+		m.candidate = current
+		m.candidateSince = time.Time{}
+		return
+	}
+
+	now := m.clock.Now()
+	if current != m.candidate || m.candidateSince.IsZero() {
+		m.candidate = current
+		m.candidateSince = now
+	}
+	if now.Sub(m.candidateSince) < m.config.Dwell {
+		return
+	}
+
+	m.failure = current
+	m.candidateSince = time.Time{}
+}
+
+// classify returns the failure indicated by a single sample of a fan commanded to spin
+func (m *FanHealthMonitor) classify(percent uint8, rpm float64, rpmErr error) FanFailure {
+	if rpmErr != nil {
+		return FanFailureTachMissing
+	}
+
+	if rpm < m.config.StallRPM {
+		if m.tachSeen {
+			return FanFailureStall
+		}
+		return FanFailureTachMissing
+	}
+
+	if m.config.MaxRPM > 0 {
+		expected := m.config.MaxRPM * float64(percent) / 100
+		if math.Abs(rpm-expected) > expected*m.config.Tolerance {
+			return FanFailureRPMOutOfRange
+		}
+	}
+
+	return FanFailureNone
+}
@@ -0,0 +1,148 @@
+package agent_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// fanHealthObserver feeds samples into the monitor like the agent, registering the returned events in the blade state
+type fanHealthObserver struct {
+	monitor       *agent.FanHealthMonitor
+	failureActive bool
+}
+
+func (o *fanHealthObserver) observe(percent uint8, rpm float64, rpmErr error) events.Event {
+	event := o.monitor.Observe(percent, rpm, rpmErr, o.failureActive)
+	switch event {
+	case events.FanFailureEvent:
+		o.failureActive = true
+	case events.FanFailureResetEvent:
+		o.failureActive = false
+	}
+	return event
+}
+
+func TestFanHealthMonitor_Disabled(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{}, nil)
+	observer := &fanHealthObserver{monitor: monitor}
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(100, 0, nil))
+	assert.Equal(t, agent.FanFailureNone, monitor.Failure())
+}
+
+func TestFanHealthMonitor_Stall(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{Enabled: true}, nil)
+	observer := &fanHealthObserver{monitor: monitor}
+
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 2500, nil))
+	assert.Equal(t, agent.FanFailureNone, monitor.Failure())
+
+	// A fan standing still at low speeds is fine
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(10, 0, nil))
+	assert.Equal(t, agent.FanFailureNone, monitor.Failure())
+
+	assert.Equal(t, events.Event(events.FanFailureEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, agent.FanFailureStall, monitor.Failure())
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(100, 0, nil))
+
+	// Low speeds don't clear a failure
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(0, 0, nil))
+	assert.Equal(t, agent.FanFailureStall, monitor.Failure())
+
+	assert.Equal(t, events.Event(events.FanFailureResetEvent), observer.observe(100, 4800, nil))
+	assert.Equal(t, agent.FanFailureNone, monitor.Failure())
+}
+
+func TestFanHealthMonitor_TachMissing(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{Enabled: true}, nil)
+	observer := &fanHealthObserver{monitor: monitor}
+
+	// The fan has never been seen spinning
+	assert.Equal(t, events.Event(events.FanFailureEvent), observer.observe(40, 0, nil))
+	assert.Equal(t, agent.FanFailureTachMissing, monitor.Failure())
+	assert.Equal(t, events.Event(events.FanFailureResetEvent), observer.observe(40, 1900, nil))
+
+	// Errors reading the fan speed
+	assert.Equal(t, events.Event(events.FanFailureEvent), observer.observe(40, 0, errors.New("no tach")))
+	assert.Equal(t, agent.FanFailureTachMissing, monitor.Failure())
+}
+
+func TestFanHealthMonitor_RPMOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{
+		Enabled:   true,
+		MaxRPM:    5000,
+		Tolerance: 0.3,
+	}, nil)
+	observer := &fanHealthObserver{monitor: monitor}
+
+	// 50% is expected to result in 2500 ± 750 RPM
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 1800, nil))
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 3200, nil))
+	assert.Equal(t, events.Event(events.FanFailureEvent), observer.observe(50, 1700, nil))
+	assert.Equal(t, agent.FanFailureRPMOutOfRange, monitor.Failure())
+
+	// Changing failure kinds keeps the blade in fan failure mode
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, agent.FanFailureStall, monitor.Failure())
+}
+
+func TestFanHealthMonitor_Dwell(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	for _, offset := range []time.Duration{0, 10, 15, 20, 40, 50} {
+		clk.On("Now").Once().Return(start.Add(offset * time.Second))
+	}
+
+	monitor := agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{
+		Enabled: true,
+		Dwell:   30 * time.Second,
+	}, clk)
+	observer := &fanHealthObserver{monitor: monitor}
+
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 2500, nil))
+
+	// A short stall is ignored
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 2500, nil))
+
+	// A persistent stall is reported
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, events.Event(events.NoopEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, events.Event(events.FanFailureEvent), observer.observe(50, 0, nil))
+	assert.Equal(t, agent.FanFailureStall, monitor.Failure())
+	clk.AssertExpectations(t)
+}
+
+func TestFanHealthMonitor_DroppedEvents(t *testing.T) {
+	t.Parallel()
+
+	monitor := agent.NewFanHealthMonitor(agent.FanHealthMonitorConfig{Enabled: true}, nil)
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(50, 2500, nil, false))
+
+	// The failure is reported until the blade enters fan failure mode
+	assert.Equal(t, events.Event(events.FanFailureEvent), monitor.Observe(50, 0, nil, false))
+	assert.Equal(t, events.Event(events.FanFailureEvent), monitor.Observe(50, 0, nil, false))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(50, 0, nil, true))
+
+	// The recovery is reported until the blade leaves fan failure mode
+	assert.Equal(t, events.Event(events.FanFailureResetEvent), monitor.Observe(50, 2500, nil, true))
+	assert.Equal(t, events.Event(events.FanFailureResetEvent), monitor.Observe(10, 0, nil, true))
+	assert.Equal(t, events.Event(events.NoopEvent), monitor.Observe(50, 2500, nil, false))
+}
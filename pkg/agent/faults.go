@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"slices"
+	"sync"
+)
+
+// Fault is a fault condition of the agent. Active faults are shown as blink codes on the top LED,
+// the blink code of a fault is its value.
+type Fault uint8
+
+const (
+	// FaultTemperatureSensor indicates that the SoC temperature can't be read
+	FaultTemperatureSensor Fault = iota + 1
+	// FaultFanControl indicates that the fan speed can't be set
+	FaultFanControl
+	// FaultSmartFanUnitMissing indicates that the smart fan unit is not detected or stopped reporting
+	FaultSmartFanUnitMissing
+	// FaultCertificate indicates that the certificates of the API server can't be loaded or generated
+	FaultCertificate
+)
+
+// AllFaults lists all fault conditions ordered by blink code
+var AllFaults = []Fault{FaultTemperatureSensor, FaultFanControl, FaultSmartFanUnitMissing, FaultCertificate}
+
+func (f Fault) String() string {
+	switch f {
+	case FaultTemperatureSensor:
+		return "temperature_sensor"
+	case FaultFanControl:
+		return "fan_control"
+	case FaultSmartFanUnitMissing:
+		return "smart_fan_unit_missing"
+	case FaultCertificate:
+		return "certificate"
+	default:
+		return "unknown"
+	}
+}
+
+// BlinkCode returns the number of flashes shown on the top LED for the fault
+func (f Fault) BlinkCode() uint8 {
+	return uint8(f)
+}
+
+// FaultSet holds the active faults
+type FaultSet struct {
+	mu     sync.Mutex
+	active []Fault
+}
+
+// Set raises or clears the fault and returns whether the set of active faults changed
+func (s *FaultSet) Set(fault Fault, active bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, found := slices.BinarySearch(s.active, fault)
+	switch {
+	case active && !found:
+		s.active = slices.Insert(s.active, idx, fault)
+	case !active && found:
+		s.active = slices.Delete(s.active, idx, idx+1)
+	default:
+		return false
+	}
+	return true
+}
+
+// Active returns the active faults ordered by blink code
+func (s *FaultSet) Active() []Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.active)
+}
+
+// IsActive returns whether the fault is active
+func (s *FaultSet) IsActive(fault Fault) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, found := slices.BinarySearch(s.active, fault)
+	return found
+}
@@ -0,0 +1,40 @@
+package agent_test
+
+import (
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultSet(t *testing.T) {
+	t.Parallel()
+
+	var faults agent.FaultSet
+	assert.Empty(t, faults.Active())
+
+	assert.True(t, faults.Set(agent.FaultCertificate, true))
+	assert.True(t, faults.Set(agent.FaultTemperatureSensor, true))
+	assert.False(t, faults.Set(agent.FaultCertificate, true))
+	assert.Equal(t, []agent.Fault{agent.FaultTemperatureSensor, agent.FaultCertificate}, faults.Active())
+	assert.True(t, faults.IsActive(agent.FaultCertificate))
+	assert.False(t, faults.IsActive(agent.FaultFanControl))
+
+	assert.False(t, faults.Set(agent.FaultFanControl, false))
+	assert.True(t, faults.Set(agent.FaultTemperatureSensor, false))
+	assert.Equal(t, []agent.Fault{agent.FaultCertificate}, faults.Active())
+}
+
+func TestFault_BlinkCode(t *testing.T) {
+	t.Parallel()
+
+	// Blink codes are documented, they must not change
+	assert.Equal(t, uint8(1), agent.FaultTemperatureSensor.BlinkCode())
+	assert.Equal(t, uint8(2), agent.FaultFanControl.BlinkCode())
+	assert.Equal(t, uint8(3), agent.FaultSmartFanUnitMissing.BlinkCode())
+	assert.Equal(t, uint8(4), agent.FaultCertificate.BlinkCode())
+
+	for _, fault := range agent.AllFaults {
+		assert.NotEqual(t, "unknown", fault.String())
+	}
+}
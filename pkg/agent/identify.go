@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+)
+
+// IdentifyTracker tracks who requested the active identify mode, since when and when it expires.
+// Every change of identify mode starts a new generation, so an expiring identify mode doesn't clear a later one.
+type IdentifyTracker struct {
+	mu    sync.Mutex
+	clock util.Clock
+
+	requester string
+	since     time.Time
+	// expiresAt is the time identify mode expires, zero if it is kept until it is confirmed
+	expiresAt  time.Time
+	generation uint64
+}
+
+// NewIdentifyTracker creates a new IdentifyTracker. If clock is nil, the real clock is used.
+func NewIdentifyTracker(clock util.Clock) *IdentifyTracker {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &IdentifyTracker{clock: clock}
+}
+
+// Activate records identify mode requested by requester, expiring after duration (0 keeps it until it is confirmed).
+// It returns the generation of the identify mode, see WaitForExpiry.
+func (t *IdentifyTracker) Activate(requester string, duration time.Duration) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	t.generation++
+	t.requester = requester
+	t.since = now
+	t.expiresAt = time.Time{}
+	if duration > 0 {
+		t.expiresAt = now.Add(duration)
+	}
+	return t.generation
+}
+
+// Clear records that identify mode was confirmed or cleared
+func (t *IdentifyTracker) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.generation++
+	t.requester = ""
+	t.since = time.Time{}
+	t.expiresAt = time.Time{}
+}
+
+// WaitForExpiry blocks for duration and returns whether the identify mode of the given generation is still active,
+// i.e. it expired and has to be cleared. It returns false if the context is cancelled first.
+func (t *IdentifyTracker) WaitForExpiry(ctx context.Context, generation uint64, duration time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.clock.After(duration):
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.generation == generation
+}
+
+// Status returns who requested the active identify mode, since when and the time left until it expires
+func (t *IdentifyTracker) Status() (requester string, since time.Time, remaining time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.expiresAt.IsZero() {
+		remaining = max(t.expiresAt.Sub(t.clock.Now()), 0)
+	}
+	return t.requester, t.since, remaining
+}
@@ -0,0 +1,106 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/agent"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifyTracker_Status(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := util.MockClock{}
+	clk.On("Now").Once().Return(start)
+	clk.On("Now").Once().Return(start.Add(20 * time.Second))
+	clk.On("Now").Once().Return(start.Add(2 * time.Minute))
+	clk.On("Now").Once().Return(start.Add(3 * time.Minute))
+
+	tracker := agent.NewIdentifyTracker(&clk)
+
+	// Inactive
+	requester, since, remaining := tracker.Status()
+	assert.Empty(t, requester)
+	assert.True(t, since.IsZero())
+	assert.Zero(t, remaining)
+
+	tracker.Activate("bladectl", time.Minute)
+	requester, since, remaining = tracker.Status()
+	assert.Equal(t, "bladectl", requester)
+	assert.Equal(t, start, since)
+	assert.Equal(t, 40*time.Second, remaining)
+
+	// The remaining time never drops below zero
+	_, _, remaining = tracker.Status()
+	assert.Zero(t, remaining)
+
+	// Identify mode without a duration never expires
+	tracker.Activate("edge-button", 0)
+	requester, since, remaining = tracker.Status()
+	assert.Equal(t, "edge-button", requester)
+	assert.Equal(t, start.Add(3*time.Minute), since)
+	assert.Zero(t, remaining)
+
+	tracker.Clear()
+	requester, since, remaining = tracker.Status()
+	assert.Empty(t, requester)
+	assert.True(t, since.IsZero())
+	assert.Zero(t, remaining)
+	clk.AssertExpectations(t)
+}
+
+func TestIdentifyTracker_WaitForExpiry(t *testing.T) {
+	t.Parallel()
+
+	expired := make(chan time.Time, 1)
+	expired <- time.Now()
+	clk := util.MockClock{}
+	clk.On("Now").Return(time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC))
+	clk.On("After", time.Minute).Once().Return(expired)
+
+	tracker := agent.NewIdentifyTracker(&clk)
+	generation := tracker.Activate("bladectl", time.Minute)
+	assert.True(t, tracker.WaitForExpiry(context.Background(), generation, time.Minute))
+	clk.AssertExpectations(t)
+}
+
+func TestIdentifyTracker_WaitForExpiry_Retriggered(t *testing.T) {
+	t.Parallel()
+
+	expired := make(chan time.Time, 1)
+	clk := util.MockClock{}
+	clk.On("Now").Return(time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC))
+	clk.On("After", time.Minute).Return(expired)
+
+	tracker := agent.NewIdentifyTracker(&clk)
+
+	// Identify mode triggered again doesn't expire with the earlier request
+	generation := tracker.Activate("bladectl", time.Minute)
+	retriggered := tracker.Activate("bladectl", time.Minute)
+	expired <- time.Now()
+	assert.False(t, tracker.WaitForExpiry(context.Background(), generation, time.Minute))
+
+	// Neither does confirmed identify mode
+	tracker.Clear()
+	expired <- time.Now()
+	assert.False(t, tracker.WaitForExpiry(context.Background(), retriggered, time.Minute))
+}
+
+func TestIdentifyTracker_WaitForExpiry_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	clk := util.MockClock{}
+	clk.On("Now").Return(time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC))
+	clk.On("After", time.Minute).Return(make(chan time.Time))
+
+	tracker := agent.NewIdentifyTracker(&clk)
+	generation := tracker.Activate("bladectl", time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, tracker.WaitForExpiry(ctx, generation, time.Minute))
+}
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
@@ -15,16 +16,53 @@ var (
 	stateMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "computeblade_state",
 		Name:      "state",
-		Help:      "ComputeBlade state (label values are critical, identify, normal)",
+		Help:      "ComputeBlade state (label values are critical, identify, fan_failure, normal)",
 	}, []string{"state"})
 )
 
+// CriticalReason is a reason for the blade to be in critical mode. Several reasons can be active at once, critical
+// mode is only left once all of them are cleared.
+type CriticalReason uint8
+
+const (
+	// CriticalReasonManual indicates that critical mode was raised via the API
+	CriticalReasonManual CriticalReason = 1 << iota
+	// CriticalReasonTemperature indicates that critical mode was raised by the critical temperature monitor
+	CriticalReasonTemperature
+	// CriticalReasonFanFailure indicates that critical mode was raised by a failed fan
+	CriticalReasonFanFailure
+)
+
+// Has reports whether any of the given reasons is active
+func (r CriticalReason) Has(reason CriticalReason) bool {
+	return r&reason != 0
+}
+
+func (r CriticalReason) String() string {
+	var reasons []string
+	if r.Has(CriticalReasonManual) {
+		reasons = append(reasons, "manual")
+	}
+	if r.Has(CriticalReasonTemperature) {
+		reasons = append(reasons, "temperature")
+	}
+	if r.Has(CriticalReasonFanFailure) {
+		reasons = append(reasons, "fan_failure")
+	}
+	if len(reasons) == 0 {
+		return "none"
+	}
+	return strings.Join(reasons, ",")
+}
+
 type ComputebladeState interface {
 	RegisterEvent(event events.Event)
 	IdentifyActive() bool
 	WaitForIdentifyConfirm(ctx context.Context) error
 	CriticalActive() bool
+	CriticalReasons() CriticalReason
 	WaitForCriticalClear(ctx context.Context) error
+	FanFailureActive() bool
 }
 
 type computebladeStateImpl struct {
@@ -33,8 +71,9 @@ type computebladeStateImpl struct {
 	// identifyActive indicates whether the blade is currently in identify mode
 	identifyActive      bool
 	identifyConfirmChan chan struct{}
-	// criticalActive indicates whether the blade is currently in critical mode
-	criticalActive      bool
+	// criticalReasons are the reasons the blade is currently in critical mode for, the blade is in critical mode
+	// while any reason is active
+	criticalReasons     CriticalReason
 	criticalConfirmChan chan struct{}
 }
 
@@ -56,12 +95,19 @@ func (s *computebladeStateImpl) RegisterEvent(event events.Event) {
 		close(s.identifyConfirmChan)
 		s.identifyConfirmChan = make(chan struct{})
 	case events.CriticalEvent:
-		s.criticalActive = true
-		s.identifyActive = false
+		s.raiseCritical(CriticalReasonManual)
 	case events.CriticalResetEvent:
-		s.criticalActive = false
-		close(s.criticalConfirmChan)
-		s.criticalConfirmChan = make(chan struct{})
+		// A manual reset also clears critical mode raised by the temperature monitor, which raises it again while the
+		// blade is still too hot. A failed fan keeps the blade in critical mode.
+		s.clearCritical(CriticalReasonManual | CriticalReasonTemperature)
+	case events.CriticalTemperatureEvent:
+		s.raiseCritical(CriticalReasonTemperature)
+	case events.CriticalTemperatureResetEvent:
+		s.clearCritical(CriticalReasonTemperature)
+	case events.FanFailureEvent:
+		s.raiseCritical(CriticalReasonFanFailure)
+	case events.FanFailureResetEvent:
+		s.clearCritical(CriticalReasonFanFailure)
 
 	default:
 		otelzap.L().Warn("Unknown event", zap.String("event", event.String()))
@@ -75,20 +121,46 @@ func (s *computebladeStateImpl) RegisterEvent(event events.Event) {
 	}
 
 	// Set critical state metric
-	if s.criticalActive {
+	if s.criticalReasons != 0 {
 		stateMetric.WithLabelValues("critical").Set(1)
 	} else {
 		stateMetric.WithLabelValues("critical").Set(0)
 	}
 
+	// Set fan failure state metric
+	if s.criticalReasons.Has(CriticalReasonFanFailure) {
+		stateMetric.WithLabelValues("fan_failure").Set(1)
+	} else {
+		stateMetric.WithLabelValues("fan_failure").Set(0)
+	}
+
 	// Set critical state metric
-	if !s.criticalActive && !s.identifyActive {
+	if s.criticalReasons == 0 && !s.identifyActive {
 		stateMetric.WithLabelValues("normal").Set(1)
 	} else {
 		stateMetric.WithLabelValues("normal").Set(0)
 	}
 }
 
+// raiseCritical adds a reason for critical mode. Critical mode ends identify mode.
+func (s *computebladeStateImpl) raiseCritical(reason CriticalReason) {
+	s.criticalReasons |= reason
+	s.identifyActive = false
+}
+
+// clearCritical removes reasons for critical mode, leaving critical mode once no reason is left
+func (s *computebladeStateImpl) clearCritical(reasons CriticalReason) {
+	if s.criticalReasons == 0 {
+		return
+	}
+
+	s.criticalReasons &^= reasons
+	if s.criticalReasons == 0 {
+		close(s.criticalConfirmChan)
+		s.criticalConfirmChan = make(chan struct{})
+	}
+}
+
 func (s *computebladeStateImpl) IdentifyActive() bool {
 	return s.identifyActive
 }
@@ -103,7 +175,17 @@ func (s *computebladeStateImpl) WaitForIdentifyConfirm(ctx context.Context) erro
 }
 
 func (s *computebladeStateImpl) CriticalActive() bool {
-	return s.criticalActive
+	return s.CriticalReasons() != 0
+}
+
+func (s *computebladeStateImpl) CriticalReasons() CriticalReason {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.criticalReasons
+}
+
+func (s *computebladeStateImpl) FanFailureActive() bool {
+	return s.CriticalReasons().Has(CriticalReasonFanFailure)
 }
 
 func (s *computebladeStateImpl) WaitForCriticalClear(ctx context.Context) error {
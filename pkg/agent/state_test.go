@@ -191,3 +191,86 @@ func TestComputeBladeState_WaitForCriticalClear_Timeout(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestComputeBladeState_RegisterEventFanFailure(t *testing.T) {
+	t.Parallel()
+
+	state := agent.NewComputeBladeState()
+
+	// A fan failure raises critical mode
+	state.RegisterEvent(events.IdentifyEvent)
+	state.RegisterEvent(events.FanFailureEvent)
+	assert.True(t, state.FanFailureActive())
+	assert.True(t, state.CriticalActive())
+	assert.False(t, state.IdentifyActive())
+
+	// Critical mode can't be cleared while the fan is failed
+	state.RegisterEvent(events.CriticalResetEvent)
+	assert.True(t, state.CriticalActive())
+
+	// A recovered fan clears critical mode raised by the fan failure only
+	state.RegisterEvent(events.FanFailureResetEvent)
+	assert.False(t, state.FanFailureActive())
+	assert.False(t, state.CriticalActive())
+}
+
+func TestComputeBladeState_CriticalReasons(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		events   []events.Event
+		expected agent.CriticalReason
+	}{
+		{
+			name:     "none",
+			expected: 0,
+		},
+		{
+			name:     "fan recovery keeps critical mode raised by the temperature",
+			events:   []events.Event{events.CriticalTemperatureEvent, events.FanFailureEvent, events.FanFailureResetEvent},
+			expected: agent.CriticalReasonTemperature,
+		},
+		{
+			name:     "fan recovery keeps critical mode raised manually",
+			events:   []events.Event{events.FanFailureEvent, events.CriticalEvent, events.FanFailureResetEvent},
+			expected: agent.CriticalReasonManual,
+		},
+		{
+			name:     "temperature reset keeps critical mode raised manually",
+			events:   []events.Event{events.CriticalEvent, events.CriticalTemperatureEvent, events.CriticalTemperatureResetEvent},
+			expected: agent.CriticalReasonManual,
+		},
+		{
+			name:     "manual reset clears manual and temperature reasons",
+			events:   []events.Event{events.CriticalEvent, events.CriticalTemperatureEvent, events.FanFailureEvent, events.CriticalResetEvent},
+			expected: agent.CriticalReasonFanFailure,
+		},
+		{
+			name:     "all reasons cleared",
+			events:   []events.Event{events.CriticalTemperatureEvent, events.FanFailureEvent, events.CriticalTemperatureResetEvent, events.FanFailureResetEvent},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := agent.NewComputeBladeState()
+			for _, event := range tc.events {
+				state.RegisterEvent(event)
+			}
+			assert.Equal(t, tc.expected, state.CriticalReasons())
+			assert.Equal(t, tc.expected != 0, state.CriticalActive())
+		})
+	}
+}
+
+func TestCriticalReason_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "none", agent.CriticalReason(0).String())
+	assert.Equal(t, "temperature,fan_failure", (agent.CriticalReasonTemperature | agent.CriticalReasonFanFailure).String())
+	assert.Equal(t, "manual", agent.CriticalReasonManual.String())
+}
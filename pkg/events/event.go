@@ -9,6 +9,10 @@ const (
 	CriticalEvent
 	CriticalResetEvent
 	EdgeButtonEvent
+	FanFailureEvent
+	FanFailureResetEvent
+	CriticalTemperatureEvent
+	CriticalTemperatureResetEvent
 )
 
 func (e Event) String() string {
@@ -25,6 +29,14 @@ func (e Event) String() string {
 		return "critical_reset"
 	case EdgeButtonEvent:
 		return "edge_button"
+	case FanFailureEvent:
+		return "fan_failure"
+	case FanFailureResetEvent:
+		return "fan_failure_reset"
+	case CriticalTemperatureEvent:
+		return "critical_temperature"
+	case CriticalTemperatureResetEvent:
+		return "critical_temperature_reset"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,104 @@
+package fancontroller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// MaxBoostDuration is the longest a fan boost may last
+const MaxBoostDuration = 24 * time.Hour
+
+// Boost raises the fan speed to at least Percent until ExpiresAt
+type Boost struct {
+	Percent   uint8
+	ExpiresAt time.Time
+}
+
+// Boosts keeps track of temporary fan boosts. Boosts are layered on top of the fan speed determined by the fan
+// controller, raising it to at least the highest active boost.
+type Boosts struct {
+	mu    sync.Mutex
+	clock util.Clock
+	// boosts holds at most one boost per percent
+	boosts []Boost
+}
+
+// NewBoosts creates a new Boosts. If clock is nil, the real clock is used.
+func NewBoosts(clock util.Clock) *Boosts {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &Boosts{clock: clock}
+}
+
+// Add adds a boost raising the fan speed to at least percent for duration.
+// A boost with the same percent is extended instead, keeping the later expiry, and the resulting boost is returned.
+func (b *Boosts) Add(percent uint8, duration time.Duration) (Boost, humane.Error) {
+	if percent == 0 || percent > 100 {
+		return Boost{}, humane.New("fan boost percent must be between 1 and 100",
+			fmt.Sprintf("Ensure the fan boost percentage is 0 < %d <= 100", percent),
+		)
+	}
+	if duration <= 0 || duration > MaxBoostDuration {
+		return Boost{}, humane.New("fan boost duration must be between 0 and 24h",
+			fmt.Sprintf("Ensure the fan boost duration is 0 < %s <= %s", duration, MaxBoostDuration),
+		)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	boost := Boost{Percent: percent, ExpiresAt: b.clock.Now().Add(duration)}
+	for i, existing := range b.boosts {
+		if existing.Percent != percent {
+			continue
+		}
+		if existing.ExpiresAt.After(boost.ExpiresAt) {
+			boost.ExpiresAt = existing.ExpiresAt
+		}
+		b.boosts[i] = boost
+		return boost, nil
+	}
+	b.boosts = append(b.boosts, boost)
+
+	return boost, nil
+}
+
+// Active returns the highest active boost, and false if no boost is active.
+func (b *Boosts) Active() (Boost, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+
+	var active Boost
+	found := false
+	remaining := b.boosts[:0]
+	for _, boost := range b.boosts {
+		if !now.Before(boost.ExpiresAt) {
+			continue
+		}
+		remaining = append(remaining, boost)
+
+		if !found || boost.Percent > active.Percent {
+			active = boost
+			found = true
+		}
+	}
+	b.boosts = remaining
+
+	return active, found
+}
+
+// Apply raises percent to the highest active boost
+func (b *Boosts) Apply(percent uint8) uint8 {
+	if boost, ok := b.Active(); ok {
+		return max(percent, boost.Percent)
+	}
+	return percent
+}
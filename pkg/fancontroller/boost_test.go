@@ -0,0 +1,98 @@
+package fancontroller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoosts(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(start)                       // Add 60% for 10m
+	clk.On("Now").Once().Return(start.Add(time.Minute))      // Add 90% for 2m
+	clk.On("Now").Once().Return(start.Add(2 * time.Minute))  // Apply
+	clk.On("Now").Once().Return(start.Add(2 * time.Minute))  // Apply
+	clk.On("Now").Once().Return(start.Add(3 * time.Minute))  // Active
+	clk.On("Now").Once().Return(start.Add(10 * time.Minute)) // Apply
+
+	boosts := fancontroller.NewBoosts(clk)
+
+	_, err := boosts.Add(60, 10*time.Minute)
+	assert.Nil(t, err)
+	boost, err := boosts.Add(90, 2*time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, fancontroller.Boost{Percent: 90, ExpiresAt: start.Add(3 * time.Minute)}, boost)
+
+	// The highest boost wins, it only raises the fan speed
+	assert.Equal(t, uint8(90), boosts.Apply(40))
+	assert.Equal(t, uint8(95), boosts.Apply(95))
+
+	// Once the highest boost expired, the next one takes over
+	boost, ok := boosts.Active()
+	assert.True(t, ok)
+	assert.Equal(t, fancontroller.Boost{Percent: 60, ExpiresAt: start.Add(10 * time.Minute)}, boost)
+
+	// Without active boosts, the fan speed is passed on
+	assert.Equal(t, uint8(40), boosts.Apply(40))
+	clk.AssertExpectations(t)
+}
+
+func TestBoosts_AddSamePercent(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(start)                       // Add 80% for 10m
+	clk.On("Now").Once().Return(start.Add(time.Minute))      // Add 80% for 1m
+	clk.On("Now").Once().Return(start.Add(2 * time.Minute))  // Add 80% for 20m
+	clk.On("Now").Once().Return(start.Add(21 * time.Minute)) // Active
+	clk.On("Now").Once().Return(start.Add(22 * time.Minute)) // Active
+
+	boosts := fancontroller.NewBoosts(clk)
+
+	_, err := boosts.Add(80, 10*time.Minute)
+	assert.Nil(t, err)
+
+	// A shorter boost with the same percent does not cut the existing one short
+	boost, err := boosts.Add(80, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, fancontroller.Boost{Percent: 80, ExpiresAt: start.Add(10 * time.Minute)}, boost)
+
+	// A longer boost with the same percent extends it
+	boost, err = boosts.Add(80, 20*time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, fancontroller.Boost{Percent: 80, ExpiresAt: start.Add(22 * time.Minute)}, boost)
+
+	boost, ok := boosts.Active()
+	assert.True(t, ok)
+	assert.Equal(t, fancontroller.Boost{Percent: 80, ExpiresAt: start.Add(22 * time.Minute)}, boost)
+
+	// Once the extended boost expired, no boost is left
+	_, ok = boosts.Active()
+	assert.False(t, ok)
+	clk.AssertExpectations(t)
+}
+
+func TestBoosts_AddErrors(t *testing.T) {
+	t.Parallel()
+
+	boosts := fancontroller.NewBoosts(nil)
+
+	_, err := boosts.Add(0, time.Minute)
+	assert.EqualError(t, err, "fan boost percent must be between 1 and 100")
+	_, err = boosts.Add(101, time.Minute)
+	assert.EqualError(t, err, "fan boost percent must be between 1 and 100")
+	_, err = boosts.Add(80, 0)
+	assert.EqualError(t, err, "fan boost duration must be between 0 and 24h")
+	_, err = boosts.Add(80, 25*time.Hour)
+	assert.EqualError(t, err, "fan boost duration must be between 0 and 24h")
+
+	_, ok := boosts.Active()
+	assert.False(t, ok)
+}
@@ -0,0 +1,255 @@
+package fancontroller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+const (
+	// DefaultCalibrationPath is where the fan calibration is stored if no path is configured
+	DefaultCalibrationPath = "/var/lib/compute-blade-agent/fan-calibration.json"
+
+	defaultCalibrationSampleInterval  = time.Second
+	defaultCalibrationSettleTimeout   = 15 * time.Second
+	defaultCalibrationSettleTolerance = 0.02
+	defaultCalibrationStallRPM        = 200
+)
+
+// CalibrationFan is the part of the blade HAL required to calibrate the fan
+type CalibrationFan interface {
+	// SetFanSpeed sets the fan speed in percent
+	SetFanSpeed(percent uint8) error
+	// GetFanRPM returns the current fan speed in RPM
+	GetFanRPM() (float64, error)
+}
+
+// CalibrationPoint is the fan speed measured at a given duty cycle
+type CalibrationPoint struct {
+	Percent uint8   `json:"percent"`
+	RPM     float64 `json:"rpm"`
+}
+
+// Calibration maps the duty cycle of a fan to its speed, and holds the duty cycles the fan starts and stalls at
+type Calibration struct {
+	// Points is the fan speed measured at each step, starting from a standing fan with increasing duty cycle
+	Points []CalibrationPoint `json:"points"`
+	// SpinUpPercent is the lowest duty cycle that starts a standing fan
+	SpinUpPercent uint8 `json:"spin_up_percent"`
+	// MinRunningPercent is the lowest duty cycle that keeps a spinning fan from stalling
+	MinRunningPercent uint8 `json:"min_running_percent"`
+	// StallRPM is the fan speed below which the fan counted as standing during the calibration
+	StallRPM float64 `json:"stall_rpm"`
+	// CalibratedAt is the time the calibration finished
+	CalibratedAt time.Time `json:"calibrated_at"`
+}
+
+// withDefaults returns the config with defaults applied for unset values
+func (c CalibrationConfig) withDefaults() CalibrationConfig {
+	if c.Path == "" {
+		c.Path = DefaultCalibrationPath
+	}
+	if len(c.Steps) == 0 {
+		for percent := 0; percent <= 100; percent += 5 {
+			c.Steps = append(c.Steps, uint8(percent))
+		}
+	}
+	if c.SampleInterval <= 0 {
+		c.SampleInterval = defaultCalibrationSampleInterval
+	}
+	if c.SettleTimeout <= 0 {
+		c.SettleTimeout = defaultCalibrationSettleTimeout
+	}
+	if c.SettleTolerance <= 0 {
+		c.SettleTolerance = defaultCalibrationSettleTolerance
+	}
+	if c.StallRPM <= 0 {
+		c.StallRPM = defaultCalibrationStallRPM
+	}
+	return c
+}
+
+// CalibrationPath returns the file the calibration is stored in
+func (c CalibrationConfig) CalibrationPath() string {
+	return c.withDefaults().Path
+}
+
+// Calibrate sweeps the fan through the configured steps, first upwards from a standing fan to find the spin-up duty cycle,
+// then downwards to find the duty cycle the fan stalls at. If clock is nil, the real clock is used.
+// The fan is left running at the highest step, the caller is responsible for restoring the fan speed.
+func Calibrate(ctx context.Context, fan CalibrationFan, config CalibrationConfig, clock util.Clock) (*Calibration, humane.Error) {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+	config = config.withDefaults()
+
+	steps := slices.Clone(config.Steps)
+	slices.Sort(steps)
+	steps = slices.Compact(steps)
+	if steps[len(steps)-1] > 100 {
+		return nil, humane.New("fan calibration steps must be between 0 and 100",
+			fmt.Sprintf("Ensure all calibration steps are <= 100, got %d", steps[len(steps)-1]),
+		)
+	}
+
+	c := &calibrator{ctx: ctx, fan: fan, config: config, clock: clock}
+
+	// Start from a standing fan
+	if _, err := c.measure(0); err != nil {
+		return nil, err
+	}
+
+	calibration := &Calibration{StallRPM: config.StallRPM}
+	spinUpFound := false
+	for _, percent := range steps {
+		rpm, err := c.measure(percent)
+		if err != nil {
+			return nil, err
+		}
+		calibration.Points = append(calibration.Points, CalibrationPoint{Percent: percent, RPM: rpm})
+
+		if !spinUpFound && rpm >= config.StallRPM {
+			calibration.SpinUpPercent = percent
+			spinUpFound = true
+		}
+	}
+	if !spinUpFound {
+		return nil, humane.New("fan did not spin up during calibration",
+			"Ensure the fan is connected and fan speed reporting is enabled",
+		)
+	}
+
+	// Slow down a spinning fan until it stalls
+	calibration.MinRunningPercent = steps[len(steps)-1]
+	for i := len(steps) - 2; i >= 0; i-- {
+		rpm, err := c.measure(steps[i])
+		if err != nil {
+			return nil, err
+		}
+		if rpm < config.StallRPM {
+			break
+		}
+		calibration.MinRunningPercent = steps[i]
+	}
+
+	// Leave the fan running at full speed
+	if err := fan.SetFanSpeed(steps[len(steps)-1]); err != nil {
+		return nil, humane.Wrap(err, "failed to set fan speed")
+	}
+
+	calibration.CalibratedAt = clock.Now()
+	return calibration, nil
+}
+
+// calibrator holds the state of a running calibration
+type calibrator struct {
+	ctx    context.Context
+	fan    CalibrationFan
+	config CalibrationConfig
+	clock  util.Clock
+}
+
+// measure sets the fan speed and returns the fan speed in RPM once it settled, or the last sample after SettleTimeout
+func (c *calibrator) measure(percent uint8) (float64, humane.Error) {
+	if err := c.fan.SetFanSpeed(percent); err != nil {
+		return 0, humane.Wrap(err, "failed to set fan speed")
+	}
+
+	rpm := -1.0
+	for waited := time.Duration(0); waited < c.config.SettleTimeout; waited += c.config.SampleInterval {
+		if err := c.ctx.Err(); err != nil {
+			return 0, humane.Wrap(err, "fan calibration aborted")
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return 0, humane.Wrap(c.ctx.Err(), "fan calibration aborted")
+		case <-c.clock.After(c.config.SampleInterval):
+		}
+
+		sample, err := c.fan.GetFanRPM()
+		if err != nil {
+			return 0, humane.Wrap(err, "failed to get fan speed")
+		}
+
+		if rpm >= 0 && math.Abs(sample-rpm) <= c.config.SettleTolerance*math.Max(rpm, c.config.StallRPM) {
+			return sample, nil
+		}
+		rpm = sample
+	}
+
+	return rpm, nil
+}
+
+// Clamp returns the duty cycle to command instead of percent, so that the fan never stalls. A fan measured below the
+// stall speed (rpm, 0 if unknown) is standing or stalled and started with at least SpinUpPercent, a running fan is kept
+// at MinRunningPercent or above. 0% is kept to allow turning off the fan on purpose.
+func (c *Calibration) Clamp(percent uint8, rpm float64) uint8 {
+	if c == nil || percent == 0 {
+		return percent
+	}
+
+	// Calibrations stored before the stall speed was recorded used the default
+	stallRPM := c.StallRPM
+	if stallRPM <= 0 {
+		stallRPM = defaultCalibrationStallRPM
+	}
+	if rpm < stallRPM {
+		return max(percent, c.SpinUpPercent)
+	}
+	return max(percent, c.MinRunningPercent)
+}
+
+// LoadCalibration reads a calibration stored with Save. If the file doesn't exist, nil is returned without error.
+func LoadCalibration(path string) (*Calibration, humane.Error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, humane.Wrap(err, "failed to read fan calibration",
+			fmt.Sprintf("ensure %s is readable", path),
+		)
+	}
+
+	var calibration Calibration
+	if err := json.Unmarshal(data, &calibration); err != nil {
+		return nil, humane.Wrap(err, "failed to parse fan calibration",
+			fmt.Sprintf("delete %s and calibrate the fan again", path),
+		)
+	}
+
+	return &calibration, nil
+}
+
+// Save writes the calibration to path, creating the parent directory if required
+func (c *Calibration) Save(path string) humane.Error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return humane.Wrap(err, "failed to encode fan calibration")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return humane.Wrap(err, "failed to create fan calibration directory",
+			fmt.Sprintf("ensure the agent can write to %s", filepath.Dir(path)),
+		)
+	}
+
+	// Write atomically, a partially written file would be rejected on startup
+	if err := util.WriteFileAtomic(path, data); err != nil {
+		return humane.Wrap(err, "failed to write fan calibration",
+			fmt.Sprintf("ensure the agent can write to %s", filepath.Dir(path)),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package fancontroller_test
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/stretchr/testify/assert"
+)
+
+// simulatedFan is a fan with inertia. Its speed approaches the target speed exponentially, a standing fan only starts
+// at spinUpPercent and a spinning fan stalls below stallPercent.
+type simulatedFan struct {
+	maxRPM        float64
+	spinUpPercent uint8
+	stallPercent  uint8
+	timeConstant  time.Duration
+
+	percent uint8
+	rpm     float64
+}
+
+func (f *simulatedFan) SetFanSpeed(percent uint8) error {
+	f.percent = percent
+	return nil
+}
+
+func (f *simulatedFan) GetFanRPM() (float64, error) {
+	return f.rpm, nil
+}
+
+func (f *simulatedFan) advance(d time.Duration) {
+	spinning := f.rpm >= 100
+	target := 0.0
+	if f.percent >= f.spinUpPercent || (spinning && f.percent >= f.stallPercent) {
+		target = f.maxRPM * float64(f.percent) / 100
+	}
+	f.rpm = target + (f.rpm-target)*math.Exp(-d.Seconds()/f.timeConstant.Seconds())
+}
+
+// simulatedFanClock advances the simulated fan whenever the calibration waits
+type simulatedFanClock struct {
+	fan *simulatedFan
+	now time.Time
+}
+
+func (c *simulatedFanClock) Now() time.Time {
+	return c.now
+}
+
+func (c *simulatedFanClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	c.fan.advance(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func newSimulatedFan() (*simulatedFan, *simulatedFanClock) {
+	fan := &simulatedFan{
+		maxRPM:        5000,
+		spinUpPercent: 30,
+		stallPercent:  15,
+		timeConstant:  2 * time.Second,
+	}
+	return fan, &simulatedFanClock{fan: fan, now: time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestCalibrate(t *testing.T) {
+	t.Parallel()
+
+	fan, clk := newSimulatedFan()
+	calibration, err := fancontroller.Calibrate(context.Background(), fan, fancontroller.CalibrationConfig{}, clk)
+	if err != nil {
+		t.Fatalf("Failed to calibrate fan: %v", err)
+	}
+
+	assert.Equal(t, uint8(30), calibration.SpinUpPercent)
+	assert.Equal(t, uint8(15), calibration.MinRunningPercent)
+	assert.Equal(t, 200.0, calibration.StallRPM)
+	assert.Equal(t, clk.now, calibration.CalibratedAt)
+
+	assert.Len(t, calibration.Points, 21)
+	for _, point := range calibration.Points {
+		expected := 0.0
+		if point.Percent >= 30 {
+			expected = 50 * float64(point.Percent)
+		}
+		// The fan speed is sampled once it changes by less than 2% per second, i.e. while still approaching the target
+		assert.InDelta(t, expected, point.RPM, 0.1*math.Max(expected, 200), "at %d%%", point.Percent)
+	}
+
+	// The fan is left running at full speed
+	assert.Equal(t, uint8(100), fan.percent)
+}
+
+func TestCalibrate_CustomSteps(t *testing.T) {
+	t.Parallel()
+
+	fan, clk := newSimulatedFan()
+	calibration, err := fancontroller.Calibrate(context.Background(), fan, fancontroller.CalibrationConfig{
+		Steps: []uint8{80, 10, 40, 20, 40},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to calibrate fan: %v", err)
+	}
+
+	assert.Equal(t, []uint8{10, 20, 40, 80}, []uint8{
+		calibration.Points[0].Percent,
+		calibration.Points[1].Percent,
+		calibration.Points[2].Percent,
+		calibration.Points[3].Percent,
+	})
+	assert.Equal(t, uint8(40), calibration.SpinUpPercent)
+	assert.Equal(t, uint8(20), calibration.MinRunningPercent)
+}
+
+func TestCalibrate_Errors(t *testing.T) {
+	t.Parallel()
+
+	// A fan that never spins
+	fan, clk := newSimulatedFan()
+	fan.maxRPM = 0
+	_, err := fancontroller.Calibrate(context.Background(), fan, fancontroller.CalibrationConfig{}, clk)
+	assert.EqualError(t, err, "fan did not spin up during calibration")
+
+	fan, clk = newSimulatedFan()
+	_, err = fancontroller.Calibrate(context.Background(), fan, fancontroller.CalibrationConfig{Steps: []uint8{50, 120}}, clk)
+	assert.EqualError(t, err, "fan calibration steps must be between 0 and 100")
+
+	fan, clk = newSimulatedFan()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = fancontroller.Calibrate(ctx, fan, fancontroller.CalibrationConfig{}, clk)
+	assert.EqualError(t, err, "fan calibration aborted")
+}
+
+func TestCalibration_Clamp(t *testing.T) {
+	t.Parallel()
+
+	calibration := &fancontroller.Calibration{SpinUpPercent: 30, MinRunningPercent: 15, StallRPM: 200}
+	assert.Equal(t, uint8(0), calibration.Clamp(0, 1500))
+	assert.Equal(t, uint8(15), calibration.Clamp(5, 1500))
+	assert.Equal(t, uint8(15), calibration.Clamp(15, 200))
+	assert.Equal(t, uint8(60), calibration.Clamp(60, 1500))
+
+	// A standing fan has to be kicked to spin up
+	assert.Equal(t, uint8(0), calibration.Clamp(0, 0))
+	assert.Equal(t, uint8(30), calibration.Clamp(15, 0))
+	assert.Equal(t, uint8(60), calibration.Clamp(60, 0))
+
+	// So does a fan that stalled although it is commanded above MinRunningPercent
+	assert.Equal(t, uint8(30), calibration.Clamp(20, 150))
+
+	// Calibrations without stall speed use the default of 200 RPM
+	legacy := &fancontroller.Calibration{SpinUpPercent: 30, MinRunningPercent: 15}
+	assert.Equal(t, uint8(30), legacy.Clamp(20, 199))
+	assert.Equal(t, uint8(20), legacy.Clamp(20, 200))
+
+	// Without calibration, the fan speed is passed on
+	var uncalibrated *fancontroller.Calibration
+	assert.Equal(t, uint8(5), uncalibrated.Clamp(5, 0))
+}
+
+func TestCalibration_ClampRestartsStalledFan(t *testing.T) {
+	t.Parallel()
+
+	fan, clk := newSimulatedFan()
+	calibration := &fancontroller.Calibration{SpinUpPercent: 30, MinRunningPercent: 15, StallRPM: 200}
+
+	// The fan runs at 20%, above MinRunningPercent
+	assert.NoError(t, fan.SetFanSpeed(calibration.Clamp(40, 0)))
+	clk.After(time.Minute)
+	assert.NoError(t, fan.SetFanSpeed(calibration.Clamp(20, fan.rpm)))
+	clk.After(time.Minute)
+	assert.Equal(t, uint8(20), fan.percent)
+	assert.InDelta(t, 1000, fan.rpm, 1)
+
+	// Something blocks the fan, which stays stalled at 20%
+	fan.rpm = 0
+	clk.After(time.Minute)
+	assert.Less(t, fan.rpm, 200.0)
+
+	// The measured stall restarts the fan with the spin-up duty cycle
+	assert.NoError(t, fan.SetFanSpeed(calibration.Clamp(20, fan.rpm)))
+	assert.Equal(t, uint8(30), fan.percent)
+	clk.After(time.Minute)
+	assert.NoError(t, fan.SetFanSpeed(calibration.Clamp(20, fan.rpm)))
+	assert.Equal(t, uint8(20), fan.percent)
+}
+
+func TestCalibration_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "calibration", "fan-calibration.json")
+
+	calibration, err := fancontroller.LoadCalibration(path)
+	assert.Nil(t, err)
+	assert.Nil(t, calibration)
+
+	calibration = &fancontroller.Calibration{
+		Points:            []fancontroller.CalibrationPoint{{Percent: 0, RPM: 0}, {Percent: 50, RPM: 2500}},
+		SpinUpPercent:     30,
+		MinRunningPercent: 15,
+		StallRPM:          200,
+		CalibratedAt:      time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC),
+	}
+	assert.Nil(t, calibration.Save(path))
+
+	loaded, err := fancontroller.LoadCalibration(path)
+	assert.Nil(t, err)
+	assert.Equal(t, calibration, loaded)
+}
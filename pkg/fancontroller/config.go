@@ -1,7 +1,48 @@
 package fancontroller
 
+import "time"
+
+// ControllerType selects the control logic of a FanController
+type ControllerType string
+
+const (
+	// ControllerTypeLinear derives the fan speed from a piecewise-linear temperature curve
+	ControllerTypeLinear ControllerType = "linear"
+	// ControllerTypePID holds a target temperature using a closed-loop PID controller
+	ControllerTypePID ControllerType = "pid"
+	// ControllerTypeExpression derives the fan speed from a user-supplied CEL expression
+	ControllerTypeExpression ControllerType = "expression"
+)
+
+// TemperatureSource selects where a temperature input is read from
+type TemperatureSource string
+
+const (
+	// TemperatureSourceSoC reads the SoC temperature (thermal_zone0)
+	TemperatureSourceSoC TemperatureSource = "soc"
+	// TemperatureSourceAirFlow reads the air flow temperature reported by the smart fan unit
+	TemperatureSourceAirFlow TemperatureSource = "airflow"
+	// TemperatureSourceSysfs reads a temperature in millidegrees from a thermal/hwmon sysfs file, e.g. of an NVMe drive
+	TemperatureSourceSysfs TemperatureSource = "sysfs"
+)
+
+// Aggregation selects how the fan speeds of multiple temperature inputs are combined
+type Aggregation string
+
+const (
+	// AggregationMax uses the highest fan speed of all inputs
+	AggregationMax Aggregation = "max"
+	// AggregationAverage uses the average fan speed of all inputs
+	AggregationAverage Aggregation = "avg"
+)
+
+// FanOverrideOpts is a fixed fan speed that takes precedence over the control logic of a FanController
 type FanOverrideOpts struct {
 	Percent uint8 `mapstructure:"speed"`
+	// Owner identifies who set the override, e.g. user@host
+	Owner string `mapstructure:"owner"`
+	// ExpiresAt is the time the override expires and automatic control resumes, zero if it never expires
+	ExpiresAt time.Time `mapstructure:"expires_at"`
 }
 
 type Step struct {
@@ -11,8 +52,104 @@ type Step struct {
 	Percent uint8 `mapstructure:"percent"`
 }
 
+// PIDConfig configures the PID fan controller
+type PIDConfig struct {
+	// TargetTemperature is the temperature the controller tries to hold
+	TargetTemperature float64 `mapstructure:"target_temperature"`
+	// Kp is the proportional gain (percent per °C)
+	Kp float64 `mapstructure:"kp"`
+	// Ki is the integral gain (percent per °C and second)
+	Ki float64 `mapstructure:"ki"`
+	// Kd is the derivative gain (percent per °C/s)
+	Kd float64 `mapstructure:"kd"`
+	// IntegralLimit bounds the integral term (in percent) to prevent windup. Defaults to MaxPercent.
+	IntegralLimit float64 `mapstructure:"integral_limit"`
+	// MinPercent is the minimum fan speed the controller commands
+	MinPercent uint8 `mapstructure:"min_percent"`
+	// MaxPercent is the maximum fan speed the controller commands. Defaults to 100.
+	MaxPercent uint8 `mapstructure:"max_percent"`
+}
+
+// InputConfig configures an additional temperature input with its own fan curve
+type InputConfig struct {
+	// Name identifies the input in logs and metrics
+	Name string `mapstructure:"name"`
+	// Source selects where the temperature is read from
+	Source TemperatureSource `mapstructure:"source"`
+	// Path is the sysfs file to read the temperature from, only used for the sysfs source. Glob patterns are supported.
+	Path string `mapstructure:"path"`
+	// Steps defines the temperature/speed steps for this input
+	Steps []Step `mapstructure:"steps"`
+}
+
+// FeedForwardConfig configures the feed-forward term, which raises the fan speed while the temperature is rising
+type FeedForwardConfig struct {
+	// Gain is the extra fan speed in percent per °C/min the temperature rises at, 0 disables feed-forward
+	Gain float64 `mapstructure:"gain"`
+	// Window is the time span of temperature samples the slope is derived from. Defaults to 30s.
+	Window time.Duration `mapstructure:"window"`
+}
+
+// CalibrationConfig configures the fan calibration
+type CalibrationConfig struct {
+	// Path is the file the calibration is stored in. Defaults to DefaultCalibrationPath.
+	Path string `mapstructure:"path"`
+	// Steps are the fan speeds (percent) swept during calibration. Defaults to 0% to 100% in steps of 5%.
+	Steps []uint8 `mapstructure:"steps"`
+	// SampleInterval is the time between two fan speed samples while waiting for the fan speed to settle
+	SampleInterval time.Duration `mapstructure:"sample_interval"`
+	// SettleTimeout is the maximum time to wait for the fan speed to settle at each step
+	SettleTimeout time.Duration `mapstructure:"settle_timeout"`
+	// SettleTolerance is the relative change between two samples below which the fan speed counts as settled
+	SettleTolerance float64 `mapstructure:"settle_tolerance"`
+	// StallRPM is the fan speed below which the fan counts as standing still
+	StallRPM float64 `mapstructure:"stall_rpm"`
+}
+
+// Profile is a named fan curve that can be activated at runtime
+type Profile struct {
+	// Steps defines the temperature/speed steps of the profile
+	Steps []Step `mapstructure:"steps"`
+}
+
 // Config configures a fan controller for the computeblade
 type Config struct {
+	// Type selects the control logic, either linear (default), pid or expression
+	Type ControllerType `mapstructure:"type"`
+
 	// Steps defines the temperature/speed steps for the fan controller
 	Steps []Step `mapstructure:"steps"`
+
+	// PID configures the PID controller, only used if Type is pid
+	PID PIDConfig `mapstructure:"pid"`
+
+	// Expression is the CEL expression returning the fan speed in percent, only used if Type is expression
+	Expression string `mapstructure:"expression"`
+
+	// Hysteresis is the temperature drop (in °C) required before the fan speed is lowered again
+	Hysteresis float64 `mapstructure:"hysteresis"`
+
+	// RampUpRate is the maximum fan speed increase in percent per second, 0 means unlimited
+	RampUpRate float64 `mapstructure:"ramp_up_rate"`
+
+	// RampDownRate is the maximum fan speed decrease in percent per second, 0 means unlimited
+	RampDownRate float64 `mapstructure:"ramp_down_rate"`
+
+	// Inputs defines additional temperature inputs. Their fan speeds are combined with the one derived from the SoC temperature.
+	Inputs []InputConfig `mapstructure:"inputs"`
+
+	// Aggregation selects how the fan speeds of all inputs are combined, either max (default) or avg
+	Aggregation Aggregation `mapstructure:"aggregation"`
+
+	// FeedForward configures the feed-forward term, which anticipates load spikes from the temperature slope
+	FeedForward FeedForwardConfig `mapstructure:"feed_forward"`
+
+	// FanOffTemperature is the temperature (in °C) below which the fan is stopped completely, 0 disables zero RPM mode
+	FanOffTemperature float64 `mapstructure:"fan_off_temperature"`
+
+	// FanOffHysteresis is the temperature rise (in °C) above FanOffTemperature required to restart the fan. Defaults to 3°C.
+	FanOffHysteresis float64 `mapstructure:"fan_off_hysteresis"`
+
+	// Calibration configures the fan calibration, which keeps the fan speed above the duty cycle the fan stalls at
+	Calibration CalibrationConfig `mapstructure:"calibration"`
 }
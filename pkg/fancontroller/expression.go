@@ -0,0 +1,205 @@
+package fancontroller
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// expressionCostLimit bounds the evaluation cost of a fan expression, so a runaway expression can't stall the fan control loop
+const expressionCostLimit = 10000
+
+// ExpressionEnvironment provides the state of the blade to fan expressions, besides the SoC temperature
+type ExpressionEnvironment interface {
+	// InputTemperatures returns the temperatures of the additional inputs in °C, keyed by input name
+	InputTemperatures() map[string]float64
+	// FanRPM returns the current fan speed in RPM
+	FanRPM() float64
+	// PowerStatus returns the power status of the blade, e.g. poe+
+	PowerStatus() string
+	// ExpressionFailed is called when the expression fails to evaluate. The fan then runs at 100%.
+	ExpressionFailed(err error)
+}
+
+// ExpressionFanController is a FanController deriving the fan speed from a CEL expression
+type ExpressionFanController interface {
+	FanController
+	// SetEnvironment sets the environment providing the blade state to the expression
+	SetEnvironment(env ExpressionEnvironment)
+}
+
+// fanControllerExpression evaluates a user-supplied CEL expression to determine the fan speed
+type fanControllerExpression struct {
+	overrideState
+
+	program cel.Program
+	clock   util.Clock
+
+	envMu sync.RWMutex
+	env   ExpressionEnvironment
+}
+
+// NewExpressionFanController compiles and type-checks the expression of the config and creates a new expression fan controller.
+// The expression has access to the following variables and must return a number, which is clamped to 0-100%:
+//   - soc (double): the SoC temperature in °C
+//   - inputs (map(string, double)): the temperatures of the additional inputs in °C, keyed by input name
+//   - rpm (double): the current fan speed in RPM
+//   - power (string): the power status of the blade (poe+ or poeOrUsbC)
+//   - hour, minute (int): the local time of day
+//
+// If env is nil, the inputs are empty, rpm is 0 and power is undefined. If clock is nil, the real clock is used.
+func NewExpressionFanController(config Config, env ExpressionEnvironment, clock util.Clock) (ExpressionFanController, humane.Error) {
+	if len(config.Expression) == 0 {
+		return nil, humane.New("fan expression must not be empty",
+			"Set fan_controller.expression, e.g. \"soc > 60 ? 100 : 40\"",
+		)
+	}
+
+	celEnv, err := cel.NewEnv(
+		cel.Variable("soc", cel.DoubleType),
+		cel.Variable("inputs", cel.MapType(cel.StringType, cel.DoubleType)),
+		cel.Variable("rpm", cel.DoubleType),
+		cel.Variable("power", cel.StringType),
+		cel.Variable("hour", cel.IntType),
+		cel.Variable("minute", cel.IntType),
+		cel.CrossTypeNumericComparisons(true),
+	)
+	if err != nil {
+		return nil, humane.Wrap(err, "failed to create fan expression environment")
+	}
+
+	ast, issues := celEnv.Compile(config.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, humane.Wrap(issues.Err(), "invalid fan expression",
+			"Ensure fan_controller.expression is a valid CEL expression",
+			"Available variables are soc, inputs, rpm, power, hour and minute",
+			"Numbers are not converted implicitly, use 100.0 instead of 100 when combining them with temperatures",
+		)
+	}
+
+	switch ast.OutputType() {
+	case cel.DoubleType, cel.IntType, cel.UintType:
+	default:
+		return nil, humane.New(fmt.Sprintf("fan expression must return a number, not %s", ast.OutputType()),
+			"Ensure fan_controller.expression evaluates to the fan speed in percent",
+		)
+	}
+
+	program, err := celEnv.Program(ast, cel.CostLimit(expressionCostLimit))
+	if err != nil {
+		return nil, humane.Wrap(err, "failed to create fan expression program")
+	}
+
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	f := &fanControllerExpression{
+		overrideState: overrideState{overrideClock: clock},
+		program:       program,
+		clock:         clock,
+		env:           env,
+	}
+
+	// Evaluate once with all configured inputs, to catch references to unknown inputs on startup
+	inputs := make(map[string]float64, len(config.Inputs))
+	for _, input := range config.Inputs {
+		inputs[input.Name] = 50
+	}
+	if _, err := f.evaluate(50, inputs, 0, "undefined"); err != nil {
+		return nil, humane.Wrap(err, "fan expression failed to evaluate",
+			"Ensure all inputs referenced by fan_controller.expression are defined in fan_controller.inputs",
+		)
+	}
+
+	return f, nil
+}
+
+// SetEnvironment sets the environment providing the blade state to the expression
+func (f *fanControllerExpression) SetEnvironment(env ExpressionEnvironment) {
+	f.envMu.Lock()
+	defer f.envMu.Unlock()
+
+	f.env = env
+}
+
+// Steps returns no steps, the expression fan controller does not use a fan curve
+func (f *fanControllerExpression) Steps() []Step {
+	return nil
+}
+
+// SetSteps always fails, the expression fan controller does not use a fan curve
+func (f *fanControllerExpression) SetSteps([]Step) humane.Error {
+	return humane.New("the expression fan controller does not use a fan curve",
+		"Change the fan expression in the agent configuration instead",
+	)
+}
+
+// GetFanSpeedPercent evaluates the expression and returns its result clamped to 0-100%.
+// If the expression fails to evaluate, the fan runs at 100% to stay on the safe side.
+func (f *fanControllerExpression) GetFanSpeedPercent(temperature float64) uint8 {
+	if percent, ok := f.overridePercent(); ok {
+		return percent
+	}
+
+	f.envMu.RLock()
+	env := f.env
+	f.envMu.RUnlock()
+
+	inputs := map[string]float64{}
+	rpm := 0.0
+	power := "undefined"
+	if env != nil {
+		inputs = env.InputTemperatures()
+		rpm = env.FanRPM()
+		power = env.PowerStatus()
+	}
+
+	percent, err := f.evaluate(temperature, inputs, rpm, power)
+	if err != nil {
+		if env != nil {
+			env.ExpressionFailed(err)
+		}
+		return 100
+	}
+
+	return percent
+}
+
+// evaluate runs the expression against the given state and clamps the result to 0-100%
+func (f *fanControllerExpression) evaluate(temperature float64, inputs map[string]float64, rpm float64, power string) (uint8, error) {
+	now := f.clock.Now()
+	out, _, err := f.program.Eval(map[string]any{
+		"soc":    temperature,
+		"inputs": inputs,
+		"rpm":    rpm,
+		"power":  power,
+		"hour":   now.Hour(),
+		"minute": now.Minute(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var result float64
+	switch v := out.(type) {
+	case types.Double:
+		result = float64(v)
+	case types.Int:
+		result = float64(v)
+	case types.Uint:
+		result = float64(v)
+	default:
+		return 0, fmt.Errorf("fan expression returned %s instead of a number", out.Type())
+	}
+
+	if math.IsNaN(result) {
+		return 100, nil
+	}
+	return uint8(math.Round(math.Max(0, math.Min(100, result)))), nil
+}
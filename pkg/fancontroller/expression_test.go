@@ -0,0 +1,184 @@
+package fancontroller_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// staticEnvironment is an ExpressionEnvironment with fixed values, recording evaluation errors
+type staticEnvironment struct {
+	inputs map[string]float64
+	rpm    float64
+	power  string
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func (e *staticEnvironment) InputTemperatures() map[string]float64 {
+	return e.inputs
+}
+
+func (e *staticEnvironment) FanRPM() float64 {
+	return e.rpm
+}
+
+func (e *staticEnvironment) PowerStatus() string {
+	return e.power
+}
+
+func (e *staticEnvironment) ExpressionFailed(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errs = append(e.errs, err)
+}
+
+func TestFanControllerExpression(t *testing.T) {
+	t.Parallel()
+
+	env := &staticEnvironment{inputs: map[string]float64{"airflow": 30}, rpm: 2000, power: "poe+"}
+	controller, err := fancontroller.NewExpressionFanController(fancontroller.Config{
+		Type:       fancontroller.ControllerTypeExpression,
+		Expression: `inputs.airflow > 35 && soc > 60 ? 100.0 : soc - 10.0`,
+		Inputs:     []fancontroller.InputConfig{{Name: "airflow", Source: fancontroller.TemperatureSourceAirFlow}},
+	}, env, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	assert.Equal(t, uint8(55), controller.GetFanSpeedPercent(65))
+	assert.Equal(t, uint8(0), controller.GetFanSpeedPercent(5)) // Clamped to 0%
+
+	env.inputs["airflow"] = 40
+	assert.Equal(t, uint8(100), controller.GetFanSpeedPercent(65))
+	assert.Equal(t, uint8(45), controller.GetFanSpeedPercent(55))
+
+	// The expression controller has no fan curve
+	assert.Empty(t, controller.Steps())
+	assert.EqualError(t, controller.SetSteps([]fancontroller.Step{{Temperature: 40, Percent: 40}}), "the expression fan controller does not use a fan curve")
+
+	// Overrides take precedence
+	controller.Override(&fancontroller.FanOverrideOpts{Percent: 42})
+	assert.Equal(t, uint8(42), controller.GetFanSpeedPercent(65))
+	assert.Empty(t, env.errs)
+}
+
+func TestFanControllerExpression_Variables(t *testing.T) {
+	t.Parallel()
+
+	clk := &util.MockClock{}
+	clk.On("Now").Return(time.Date(2025, time.June, 6, 23, 30, 0, 0, time.UTC))
+
+	testCases := []struct {
+		expression string
+		expected   uint8
+	}{
+		{`rpm / 20.0`, 100},
+		{`power == "poe+" ? 70 : 30`, 70},
+		{`hour >= 22 || hour < 7 ? 20 : 60`, 20},
+		{`minute`, 30},
+		{`250`, 100}, // Clamped to 100%
+		{`-5`, 0},
+		{`42.6`, 43},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			t.Parallel()
+
+			env := &staticEnvironment{rpm: 2000, power: "poe+"}
+			controller, err := fancontroller.NewExpressionFanController(fancontroller.Config{Expression: tc.expression}, env, clk)
+			if err != nil {
+				t.Fatalf("Failed to create fan controller: %v", err)
+			}
+			assert.Equal(t, tc.expected, controller.GetFanSpeedPercent(50))
+		})
+	}
+}
+
+func TestFanControllerExpression_EvaluationError(t *testing.T) {
+	t.Parallel()
+
+	env := &staticEnvironment{inputs: map[string]float64{}}
+	controller, err := fancontroller.NewExpressionFanController(fancontroller.Config{
+		Expression: `inputs.nvme > 50 ? 100 : 30`,
+		Inputs:     []fancontroller.InputConfig{{Name: "nvme", Source: fancontroller.TemperatureSourceSysfs}},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Without environment, the inputs are empty and the fan runs at full speed
+	assert.Equal(t, uint8(100), controller.GetFanSpeedPercent(40))
+
+	// Evaluation errors are reported to the environment
+	controller.SetEnvironment(env)
+	assert.Equal(t, uint8(100), controller.GetFanSpeedPercent(40))
+	assert.Len(t, env.errs, 1)
+
+	env.inputs["nvme"] = 40
+	assert.Equal(t, uint8(30), controller.GetFanSpeedPercent(40))
+	assert.Len(t, env.errs, 1)
+}
+
+func TestFanControllerExpression_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config fancontroller.Config
+		errMsg string
+	}{
+		{
+			name:   "Empty expression",
+			config: fancontroller.Config{},
+			errMsg: "fan expression must not be empty",
+		},
+		{
+			name:   "Syntax error",
+			config: fancontroller.Config{Expression: `soc >`},
+			errMsg: "invalid fan expression",
+		},
+		{
+			name:   "Unknown variable",
+			config: fancontroller.Config{Expression: `gpu > 60 ? 100 : 30`},
+			errMsg: "invalid fan expression",
+		},
+		{
+			name:   "Mixed result types",
+			config: fancontroller.Config{Expression: `soc > 60 ? 100 : soc`},
+			errMsg: "invalid fan expression",
+		},
+		{
+			name:   "Wrong result type",
+			config: fancontroller.Config{Expression: `soc > 60`},
+			errMsg: "fan expression must return a number, not bool",
+		},
+		{
+			name:   "Unknown input",
+			config: fancontroller.Config{Expression: `inputs.airflw > 35 ? 100 : 30`, Inputs: []fancontroller.InputConfig{{Name: "airflow"}}},
+			errMsg: "fan expression failed to evaluate",
+		},
+	}
+
+	for _, tc := range testCases {
+		config := tc.config
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := fancontroller.NewExpressionFanController(config, nil, nil)
+			assert.EqualError(t, err, expectedErrMsg)
+		})
+	}
+
+	// The generic constructor selects the expression controller
+	controller, err := fancontroller.New(fancontroller.Config{Type: fancontroller.ControllerTypeExpression, Expression: `40`}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(40), controller.GetFanSpeedPercent(90))
+	assert.Implements(t, (*fancontroller.ExpressionFanController)(nil), controller)
+}
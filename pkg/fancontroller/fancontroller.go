@@ -2,9 +2,11 @@ package fancontroller
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
 	"github.com/sierrasoftworks/humane-errors-go"
 )
 
@@ -15,21 +17,59 @@ type FanController interface {
 	// IsAutomaticSpeed returns true if the FanSpeed is determined by the fan controller logic, or false if determined
 	// by an FanOverrideOpts
 	IsAutomaticSpeed() bool
+	// ActiveOverride returns the active FanOverrideOpts, or nil if the fan speed is determined automatically.
+	// Expired overrides are cleared.
+	ActiveOverride() *FanOverrideOpts
 
 	// Steps returns the list of temperature and fan speed steps configured for the fan controller.
 	Steps() []Step
+	// SetSteps atomically replaces the fan curve. The steps are validated the same way as when creating the controller.
+	SetSteps(steps []Step) humane.Error
+}
+
+// New creates a new FanController using the control logic selected in the config. If clock is nil, the real clock is used.
+func New(config Config, clock util.Clock) (FanController, humane.Error) {
+	switch config.Type {
+	case "", ControllerTypeLinear:
+		return NewLinearFanController(config, clock)
+	case ControllerTypePID:
+		return NewPIDFanController(config, clock)
+	case ControllerTypeExpression:
+		return NewExpressionFanController(config, nil, clock)
+	default:
+		return nil, humane.New(fmt.Sprintf("unknown fan controller type %q", config.Type),
+			fmt.Sprintf("valid types are: [%s, %s, %s]", ControllerTypeLinear, ControllerTypePID, ControllerTypeExpression),
+		)
+	}
 }
 
 // FanController is a simple fan controller that reacts to temperature changes with a linear function
 type fanControllerLinear struct {
-	mu           sync.Mutex
-	overrideOpts *FanOverrideOpts
-	config       Config
+	overrideState
+
+	mu    sync.RWMutex
+	steps []Step
 }
 
-// NewLinearFanController creates a new FanControllerLinear
-func NewLinearFanController(config Config) (FanController, humane.Error) {
-	steps := config.Steps
+// NewLinearFanController creates a new FanControllerLinear.
+// The steps are sorted by temperature and must describe a non-decreasing curve. A single step results in a constant
+// fan speed, no steps at all result in the fan running at 100%. If clock is nil, the real clock is used to expire
+// overrides.
+func NewLinearFanController(config Config, clock util.Clock) (FanController, humane.Error) {
+	steps, err := validateSteps(config.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fanControllerLinear{
+		overrideState: overrideState{overrideClock: clock},
+		steps:         steps,
+	}, nil
+}
+
+// validateSteps returns a copy of steps sorted by temperature, or an error if they don't describe a valid fan curve
+func validateSteps(steps []Step) ([]Step, humane.Error) {
+	steps = slices.Clone(steps)
 
 	// Sort steps by temperature
 	sort.Slice(steps, func(i, j int) bool {
@@ -62,46 +102,76 @@ func NewLinearFanController(config Config) (FanController, humane.Error) {
 		}
 	}
 
-	return &fanControllerLinear{
-		config: config,
-	}, nil
+	return steps, nil
 }
 
 func (f *fanControllerLinear) Steps() []Step {
-	return f.config.Steps
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return slices.Clone(f.steps)
 }
 
-func (f *fanControllerLinear) Override(opts *FanOverrideOpts) {
+// SetSteps validates and atomically replaces the fan curve. On error, the current curve is kept.
+func (f *fanControllerLinear) SetSteps(steps []Step) humane.Error {
+	steps, err := validateSteps(steps)
+	if err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	f.overrideOpts = opts
+	f.steps = steps
+
+	return nil
 }
 
-// GetFanSpeedPercent returns the fan speed in percent based on the current temperature
+// GetFanSpeedPercent returns the fan speed in percent based on the current temperature.
+// The speed is interpolated linearly between the two steps surrounding the temperature. Below the first and above the
+// last step, the speed of the respective step is used. Without any steps, the fan runs at 100% to stay on the safe side.
 func (f *fanControllerLinear) GetFanSpeedPercent(temperature float64) uint8 {
-	f.mu.Lock()
-	defer f.mu.Unlock()
+	if percent, ok := f.overridePercent(); ok {
+		return percent
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 
-	if f.overrideOpts != nil {
-		return f.overrideOpts.Percent
+	return interpolateSteps(f.steps, temperature)
+}
+
+// interpolateSteps returns the fan speed for the given temperature on the piecewise-linear curve defined by steps.
+// steps must be sorted by temperature.
+func interpolateSteps(steps []Step, temperature float64) uint8 {
+	if len(steps) == 0 {
+		return 100
 	}
 
-	if temperature <= f.config.Steps[0].Temperature {
-		return f.config.Steps[0].Percent
+	first := steps[0]
+	last := steps[len(steps)-1]
+	if temperature <= first.Temperature {
+		return first.Percent
 	}
-	if temperature >= f.config.Steps[1].Temperature {
-		return f.config.Steps[1].Percent
+	if temperature >= last.Temperature {
+		return last.Percent
 	}
 
-	// Calculate slope
-	slope := float64(f.config.Steps[1].Percent-f.config.Steps[0].Percent) / (f.config.Steps[1].Temperature - f.config.Steps[0].Temperature)
+	// Find the segment containing the temperature
+	for i := 1; i < len(steps); i++ {
+		lower := steps[i-1]
+		upper := steps[i]
+		if temperature >= upper.Temperature {
+			continue
+		}
+
+		// Calculate slope
+		slope := float64(upper.Percent-lower.Percent) / (upper.Temperature - lower.Temperature)
 
-	// Calculate speed
-	speed := float64(f.config.Steps[0].Percent) + slope*(temperature-f.config.Steps[0].Temperature)
+		// Calculate speed
+		speed := float64(lower.Percent) + slope*(temperature-lower.Temperature)
 
-	return uint8(speed)
-}
+		return uint8(speed)
+	}
 
-func (f *fanControllerLinear) IsAutomaticSpeed() bool {
-	return f.overrideOpts == nil
+	return last.Percent
 }
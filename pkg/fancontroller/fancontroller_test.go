@@ -2,8 +2,10 @@ package fancontroller_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,7 +19,7 @@ func TestFanControllerLinear_GetFanSpeed(t *testing.T) {
 		},
 	}
 
-	controller, err := fancontroller.NewLinearFanController(config)
+	controller, err := fancontroller.NewLinearFanController(config, nil)
 	if err != nil {
 		t.Fatalf("Failed to create fan controller: %v", err)
 	}
@@ -45,6 +47,80 @@ func TestFanControllerLinear_GetFanSpeed(t *testing.T) {
 	}
 }
 
+func TestFanControllerLinear_GetFanSpeedMultiStep(t *testing.T) {
+	t.Parallel()
+
+	config := fancontroller.Config{
+		Steps: []fancontroller.Step{
+			{Temperature: 60, Percent: 100},
+			{Temperature: 40, Percent: 30},
+			{Temperature: 50, Percent: 50},
+			{Temperature: 55, Percent: 80},
+			{Temperature: 45, Percent: 40},
+		},
+	}
+
+	controller, err := fancontroller.NewLinearFanController(config, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	testCases := []struct {
+		temperature float64
+		expected    uint8
+	}{
+		{30, 30},   // Below the first step
+		{40, 30},   // On the first step
+		{42.5, 35}, // Between first and second step
+		{45, 40},   // On the second step
+		{47.5, 45}, // Between second and third step
+		{52.5, 65}, // Between third and fourth step
+		{55, 80},   // On the fourth step
+		{57.5, 90}, // Between fourth and last step
+		{60, 100},  // On the last step
+		{75, 100},  // Above the last step
+	}
+
+	for _, tc := range testCases {
+		expected := tc.expected
+		temperature := tc.temperature
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, expected, controller.GetFanSpeedPercent(temperature))
+		})
+	}
+}
+
+func TestFanControllerLinear_GetFanSpeedSingleStep(t *testing.T) {
+	t.Parallel()
+
+	controller, err := fancontroller.NewLinearFanController(fancontroller.Config{
+		Steps: []fancontroller.Step{
+			{Temperature: 50, Percent: 60},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	assert.Equal(t, uint8(60), controller.GetFanSpeedPercent(20))
+	assert.Equal(t, uint8(60), controller.GetFanSpeedPercent(50))
+	assert.Equal(t, uint8(60), controller.GetFanSpeedPercent(80))
+}
+
+func TestFanControllerLinear_GetFanSpeedNoSteps(t *testing.T) {
+	t.Parallel()
+
+	controller, err := fancontroller.NewLinearFanController(fancontroller.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Without a curve, the fan runs at full speed
+	assert.Equal(t, uint8(100), controller.GetFanSpeedPercent(20))
+	assert.Equal(t, uint8(100), controller.GetFanSpeedPercent(80))
+}
+
 func TestFanControllerLinear_GetFanSpeedWithOverride(t *testing.T) {
 	t.Parallel()
 
@@ -55,7 +131,7 @@ func TestFanControllerLinear_GetFanSpeedWithOverride(t *testing.T) {
 		},
 	}
 
-	controller, err := fancontroller.NewLinearFanController(config)
+	controller, err := fancontroller.NewLinearFanController(config, nil)
 	if err != nil {
 		t.Fatalf("Failed to create fan controller: %v", err)
 	}
@@ -110,6 +186,17 @@ func TestFanControllerLinear_ConstructionErrors(t *testing.T) {
 			},
 			errMsg: "fan percent must not decrease",
 		},
+		{
+			name: "Percentages must not decrease on any step",
+			config: fancontroller.Config{
+				Steps: []fancontroller.Step{
+					{Temperature: 20, Percent: 30},
+					{Temperature: 30, Percent: 60},
+					{Temperature: 40, Percent: 50},
+				},
+			},
+			errMsg: "fan percent must not decrease",
+		},
 		{
 			name: "InvalidSpeedRange",
 			config: fancontroller.Config{
@@ -127,10 +214,113 @@ func TestFanControllerLinear_ConstructionErrors(t *testing.T) {
 		expectedErrMsg := tc.errMsg
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			_, err := fancontroller.NewLinearFanController(config)
+			_, err := fancontroller.NewLinearFanController(config, nil)
 
 			assert.NotNil(t, err, "Expected error with message '%s', but got no error", expectedErrMsg)
 			assert.EqualError(t, err, expectedErrMsg)
 		})
 	}
 }
+
+func TestFanControllerLinear_SetSteps(t *testing.T) {
+	t.Parallel()
+
+	controller, err := fancontroller.NewLinearFanController(fancontroller.Config{
+		Steps: []fancontroller.Step{
+			{Temperature: 20, Percent: 30},
+			{Temperature: 30, Percent: 60},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Steps are sorted and applied right away
+	err = controller.SetSteps([]fancontroller.Step{
+		{Temperature: 60, Percent: 100},
+		{Temperature: 40, Percent: 20},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []fancontroller.Step{
+		{Temperature: 40, Percent: 20},
+		{Temperature: 60, Percent: 100},
+	}, controller.Steps())
+	assert.Equal(t, uint8(60), controller.GetFanSpeedPercent(50))
+
+	// Invalid curves are rejected and the current curve is kept
+	err = controller.SetSteps([]fancontroller.Step{
+		{Temperature: 40, Percent: 80},
+		{Temperature: 60, Percent: 20},
+	})
+	assert.EqualError(t, err, "fan percent must not decrease")
+	err = controller.SetSteps([]fancontroller.Step{{Temperature: 40, Percent: 101}})
+	assert.EqualError(t, err, "fan percent must be between 0 and 100")
+	assert.Equal(t, uint8(60), controller.GetFanSpeedPercent(50))
+}
+
+func TestFanControllerPID_SetSteps(t *testing.T) {
+	t.Parallel()
+
+	controller, err := fancontroller.New(fancontroller.Config{
+		Type: fancontroller.ControllerTypePID,
+		PID:  fancontroller.PIDConfig{TargetTemperature: 50, Kp: 2},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	err = controller.SetSteps([]fancontroller.Step{{Temperature: 40, Percent: 50}})
+	assert.EqualError(t, err, "the pid fan controller does not use a fan curve")
+}
+
+func TestFanControllerLinear_OverrideExpiry(t *testing.T) {
+	t.Parallel()
+
+	controller, err := fancontroller.NewLinearFanController(fancontroller.Config{
+		Steps: []fancontroller.Step{{Temperature: 20, Percent: 30}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Active lease
+	expiresAt := time.Now().Add(time.Hour)
+	controller.Override(&fancontroller.FanOverrideOpts{Percent: 99, Owner: "alice@workstation", ExpiresAt: expiresAt})
+	assert.False(t, controller.IsAutomaticSpeed())
+	assert.Equal(t, uint8(99), controller.GetFanSpeedPercent(20))
+	assert.Equal(t, &fancontroller.FanOverrideOpts{Percent: 99, Owner: "alice@workstation", ExpiresAt: expiresAt}, controller.ActiveOverride())
+
+	// Expired lease
+	controller.Override(&fancontroller.FanOverrideOpts{Percent: 99, ExpiresAt: time.Now().Add(-time.Second)})
+	assert.Nil(t, controller.ActiveOverride())
+	assert.True(t, controller.IsAutomaticSpeed())
+	assert.Equal(t, uint8(30), controller.GetFanSpeedPercent(20))
+}
+
+func TestFanControllerLinear_OverrideExpiryClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(start)                       // IsAutomaticSpeed
+	clk.On("Now").Once().Return(start.Add(9 * time.Minute))  // GetFanSpeedPercent
+	clk.On("Now").Once().Return(start.Add(10 * time.Minute)) // IsAutomaticSpeed
+
+	controller, err := fancontroller.NewLinearFanController(fancontroller.Config{
+		Steps: []fancontroller.Step{{Temperature: 20, Percent: 30}},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// The lease is checked against the injected clock, not the real one
+	controller.Override(&fancontroller.FanOverrideOpts{Percent: 99, Owner: "alice@workstation", ExpiresAt: start.Add(10 * time.Minute)})
+	assert.False(t, controller.IsAutomaticSpeed())
+	assert.Equal(t, uint8(99), controller.GetFanSpeedPercent(20))
+
+	// The expired lease is cleared
+	assert.True(t, controller.IsAutomaticSpeed())
+	assert.Nil(t, controller.ActiveOverride())
+	assert.Equal(t, uint8(30), controller.GetFanSpeedPercent(20))
+	clk.AssertExpectations(t)
+}
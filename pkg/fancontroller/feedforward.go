@@ -0,0 +1,120 @@
+package fancontroller
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// defaultFeedForwardWindow is the time span of temperature samples the slope is derived from
+const defaultFeedForwardWindow = 30 * time.Second
+
+// temperatureSample is a temperature reading at a point in time
+type temperatureSample struct {
+	at          time.Time
+	temperature float64
+}
+
+// FeedForward anticipates load spikes by adding extra fan speed proportional to the rate the temperature rises at.
+// The slope is derived by a least-squares fit over the temperature samples within a sliding window, once the samples
+// cover at least half the window.
+// Falling temperatures don't reduce the fan speed, that is left to the control logic.
+type FeedForward struct {
+	mu     sync.Mutex
+	config FeedForwardConfig
+	clock  util.Clock
+
+	samples []temperatureSample
+	// slope is the slope of the most recent fit in °C per minute
+	slope float64
+}
+
+// NewFeedForward creates a new FeedForward using the feed-forward settings of the config. A gain of 0 disables it.
+// If clock is nil, the real clock is used.
+func NewFeedForward(config Config, clock util.Clock) (*FeedForward, humane.Error) {
+	ffConfig := config.FeedForward
+	if ffConfig.Gain < 0 || ffConfig.Window < 0 {
+		return nil, humane.New("fan feed-forward settings must not be negative",
+			fmt.Sprintf("Ensure feed_forward.gain (%.2f) and feed_forward.window (%s) are >= 0", ffConfig.Gain, ffConfig.Window),
+		)
+	}
+	if ffConfig.Window == 0 {
+		ffConfig.Window = defaultFeedForwardWindow
+	}
+
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &FeedForward{
+		config: ffConfig,
+		clock:  clock,
+	}, nil
+}
+
+// Percent records the temperature and returns percent raised by the feed-forward term, capped at 100%
+func (f *FeedForward) Percent(temperature float64, percent uint8) uint8 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.config.Gain == 0 {
+		return percent
+	}
+
+	now := f.clock.Now()
+	f.samples = append(f.samples, temperatureSample{at: now, temperature: temperature})
+
+	// Drop samples that left the window
+	cutoff := now.Add(-f.config.Window)
+	first := 0
+	for first < len(f.samples) && f.samples[first].at.Before(cutoff) {
+		first++
+	}
+	f.samples = f.samples[first:]
+
+	// A short span of samples amplifies sensor noise, wait until half the window is covered
+	if now.Sub(f.samples[0].at) < f.config.Window/2 {
+		f.slope = 0
+	} else {
+		f.slope = fitSlope(f.samples)
+	}
+	extra := math.Max(0, f.config.Gain*f.slope)
+
+	return uint8(math.Min(100, float64(percent)+math.Round(extra)))
+}
+
+// Slope returns the temperature slope of the most recent sample in °C per minute
+func (f *FeedForward) Slope() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.slope
+}
+
+// fitSlope returns the slope of the least-squares line through the samples in °C per minute, 0 for less than two samples
+func fitSlope(samples []temperatureSample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+
+	origin := samples[0].at
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(origin).Minutes()
+		sumX += x
+		sumY += s.temperature
+		sumXY += x * s.temperature
+		sumXX += x * x
+	}
+
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
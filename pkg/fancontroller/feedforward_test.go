@@ -0,0 +1,208 @@
+package fancontroller_test
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// traceSample is a temperature recorded by the fan controller
+type traceSample struct {
+	at          time.Duration
+	temperature float64
+}
+
+// loadTrace reads a recorded temperature trace with one "seconds,temperature" sample per line
+func loadTrace(t *testing.T, path string) []traceSample {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open trace: %v", err)
+	}
+	defer f.Close()
+
+	var trace []traceSample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		seconds, temperature, _ := strings.Cut(line, ",")
+		s, err := strconv.ParseFloat(seconds, 64)
+		if err != nil {
+			t.Fatalf("Invalid trace line %q: %v", line, err)
+		}
+		temp, err := strconv.ParseFloat(temperature, 64)
+		if err != nil {
+			t.Fatalf("Invalid trace line %q: %v", line, err)
+		}
+		trace = append(trace, traceSample{at: time.Duration(s * float64(time.Second)), temperature: temp})
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read trace: %v", err)
+	}
+
+	return trace
+}
+
+// traceClock returns a mock clock returning the timestamps of the trace
+func traceClock(trace []traceSample) *util.MockClock {
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	for _, sample := range trace {
+		clk.On("Now").Once().Return(start.Add(sample.at))
+	}
+	return clk
+}
+
+// feedForwardCurve is the fan curve the feed-forward term is added to
+var feedForwardCurve = fancontroller.Config{
+	Steps: []fancontroller.Step{
+		{Temperature: 40, Percent: 20},
+		{Temperature: 75, Percent: 100},
+	},
+	FeedForward: fancontroller.FeedForwardConfig{
+		Gain:   2,
+		Window: 30 * time.Second,
+	},
+}
+
+func TestFeedForward_LoadSpike(t *testing.T) {
+	t.Parallel()
+
+	trace := loadTrace(t, "testdata/kernel_build.csv")
+	clk := traceClock(trace)
+
+	controller, err := fancontroller.NewLinearFanController(feedForwardCurve, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+	feedForward, err := fancontroller.NewFeedForward(feedForwardCurve, clk)
+	if err != nil {
+		t.Fatalf("Failed to create feed-forward: %v", err)
+	}
+
+	// Time at which the fan first reaches 80% with and without feed-forward
+	const highSpeed = 80
+	curveReached, feedForwardReached := time.Duration(-1), time.Duration(-1)
+	for _, sample := range trace {
+		curve := controller.GetFanSpeedPercent(sample.temperature)
+		speed := feedForward.Percent(sample.temperature, curve)
+		assert.GreaterOrEqual(t, speed, curve, "at %s", sample.at)
+		assert.LessOrEqual(t, speed, uint8(100), "at %s", sample.at)
+
+		if curve >= highSpeed && curveReached < 0 {
+			curveReached = sample.at
+		}
+		if speed >= highSpeed && feedForwardReached < 0 {
+			feedForwardReached = sample.at
+		}
+
+		switch {
+		case sample.at < 60*time.Second:
+			// Idle before the build
+			assert.InDelta(t, curve, speed, 2, "at %s", sample.at)
+		case sample.at >= 240*time.Second && sample.at <= 300*time.Second:
+			// The temperature levelled off
+			assert.InDelta(t, curve, speed, 5, "at %s", sample.at)
+		case sample.at >= 330*time.Second:
+			// Falling temperatures are left to the fan curve
+			assert.Equal(t, curve, speed, "at %s", sample.at)
+			assert.Negative(t, feedForward.Slope(), "at %s", sample.at)
+		}
+	}
+
+	assert.Positive(t, curveReached)
+	assert.Positive(t, feedForwardReached)
+	assert.GreaterOrEqual(t, curveReached-feedForwardReached, 30*time.Second,
+		"expected feed-forward to reach %d%% at least 30s earlier (curve: %s, feed-forward: %s)", highSpeed, curveReached, feedForwardReached)
+	clk.AssertExpectations(t)
+}
+
+func TestFeedForward_IdleNoise(t *testing.T) {
+	t.Parallel()
+
+	trace := loadTrace(t, "testdata/idle.csv")
+	clk := traceClock(trace)
+
+	feedForward, err := fancontroller.NewFeedForward(feedForwardCurve, clk)
+	if err != nil {
+		t.Fatalf("Failed to create feed-forward: %v", err)
+	}
+
+	// Sensor noise must not make the fan hunt
+	for _, sample := range trace {
+		speed := feedForward.Percent(sample.temperature, 40)
+		assert.LessOrEqual(t, speed, uint8(45), "at %s", sample.at)
+	}
+	clk.AssertExpectations(t)
+}
+
+func TestFeedForward_Slope(t *testing.T) {
+	t.Parallel()
+
+	trace := []traceSample{
+		{0, 50},
+		{10 * time.Second, 51},
+		{20 * time.Second, 52},
+		{30 * time.Second, 53},
+		// Samples older than the window are dropped, the last two samples cover enough of the window again
+		{70 * time.Second, 60},
+		{90 * time.Second, 60},
+	}
+	clk := traceClock(trace)
+
+	feedForward, err := fancontroller.NewFeedForward(fancontroller.Config{
+		FeedForward: fancontroller.FeedForwardConfig{Gain: 1, Window: 30 * time.Second},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create feed-forward: %v", err)
+	}
+
+	// The slope is only derived once the samples cover half the window
+	expectedSlopes := []float64{0, 0, 6, 6, 0, 0}
+	expectedPercent := []uint8{30, 30, 36, 36, 30, 30}
+	for idx, sample := range trace {
+		assert.Equal(t, expectedPercent[idx], feedForward.Percent(sample.temperature, 30), "sample %d", idx)
+		assert.InDelta(t, expectedSlopes[idx], feedForward.Slope(), 0.001, "sample %d", idx)
+	}
+	clk.AssertExpectations(t)
+}
+
+func TestFeedForward_Disabled(t *testing.T) {
+	t.Parallel()
+
+	clk := &util.MockClock{}
+	feedForward, err := fancontroller.NewFeedForward(fancontroller.Config{}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create feed-forward: %v", err)
+	}
+
+	assert.Equal(t, uint8(30), feedForward.Percent(50, 30))
+	assert.Equal(t, uint8(30), feedForward.Percent(80, 30))
+	clk.AssertNotCalled(t, "Now")
+}
+
+func TestFeedForward_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := fancontroller.NewFeedForward(fancontroller.Config{
+		FeedForward: fancontroller.FeedForwardConfig{Gain: -1},
+	}, nil)
+	assert.EqualError(t, err, "fan feed-forward settings must not be negative")
+
+	_, err = fancontroller.NewFeedForward(fancontroller.Config{
+		FeedForward: fancontroller.FeedForwardConfig{Gain: 1, Window: -time.Second},
+	}, nil)
+	assert.EqualError(t, err, "fan feed-forward settings must not be negative")
+}
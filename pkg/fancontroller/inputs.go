@@ -0,0 +1,139 @@
+package fancontroller
+
+import (
+	"fmt"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// Input is an additional temperature input with its own fan curve. Inputs of the expression fan controller have no fan
+// curve, they only provide their temperature to the expression.
+type Input struct {
+	config   InputConfig
+	curve    FanController
+	smoother *Smoother
+}
+
+// NewInputs creates the additional temperature inputs defined in the config.
+// Each input uses a linear fan curve following the same rules as NewLinearFanController, and the hysteresis of the config.
+// Inputs of the expression fan controller must not define a fan curve.
+func NewInputs(config Config) ([]*Input, humane.Error) {
+	switch config.Aggregation {
+	case "", AggregationMax, AggregationAverage:
+	default:
+		return nil, humane.New(fmt.Sprintf("unknown fan speed aggregation %q", config.Aggregation),
+			fmt.Sprintf("valid aggregations are: [%s, %s]", AggregationMax, AggregationAverage),
+		)
+	}
+
+	names := make(map[string]struct{}, len(config.Inputs))
+	inputs := make([]*Input, 0, len(config.Inputs))
+	for _, inputConfig := range config.Inputs {
+		if len(inputConfig.Name) == 0 {
+			return nil, humane.New("fan controller input must have a name",
+				"Set a unique name for every entry in fan_controller.inputs",
+			)
+		}
+		if _, ok := names[inputConfig.Name]; ok {
+			return nil, humane.New(fmt.Sprintf("duplicate fan controller input %q", inputConfig.Name),
+				"Ensure every entry in fan_controller.inputs has a unique name",
+			)
+		}
+		names[inputConfig.Name] = struct{}{}
+
+		switch inputConfig.Source {
+		case TemperatureSourceSoC, TemperatureSourceAirFlow:
+		case TemperatureSourceSysfs:
+			if len(inputConfig.Path) == 0 {
+				return nil, humane.New(fmt.Sprintf("fan controller input %q has no path", inputConfig.Name),
+					"Set the path of the sysfs file to read the temperature from, e.g. /sys/class/nvme/nvme0/hwmon*/temp1_input",
+				)
+			}
+		default:
+			return nil, humane.New(fmt.Sprintf("fan controller input %q has unknown source %q", inputConfig.Name, inputConfig.Source),
+				fmt.Sprintf("valid sources are: [%s, %s, %s]", TemperatureSourceSoC, TemperatureSourceAirFlow, TemperatureSourceSysfs),
+			)
+		}
+
+		// The expression decides on the fan speed by itself, its inputs only provide temperatures
+		if config.Type == ControllerTypeExpression {
+			if len(inputConfig.Steps) > 0 {
+				return nil, humane.New(fmt.Sprintf("fan controller input %q has steps, which the expression fan controller does not use", inputConfig.Name),
+					"Remove the steps of the input and use its temperature in fan_controller.expression instead",
+				)
+			}
+			inputs = append(inputs, &Input{config: inputConfig})
+			continue
+		}
+
+		if len(inputConfig.Steps) == 0 {
+			return nil, humane.New(fmt.Sprintf("fan controller input %q has no steps", inputConfig.Name),
+				"Define at least one temperature/speed step for every input",
+			)
+		}
+
+		curve, herr := NewLinearFanController(Config{Steps: inputConfig.Steps}, nil)
+		if herr != nil {
+			return nil, humane.Wrap(herr, fmt.Sprintf("invalid fan curve for input %q", inputConfig.Name))
+		}
+
+		smoother, herr := NewSmoother(Config{Hysteresis: config.Hysteresis}, nil)
+		if herr != nil {
+			return nil, herr
+		}
+
+		inputs = append(inputs, &Input{
+			config:   inputConfig,
+			curve:    curve,
+			smoother: smoother,
+		})
+	}
+
+	return inputs, nil
+}
+
+// Name returns the name of the input
+func (i *Input) Name() string {
+	return i.config.Name
+}
+
+// Source returns where the temperature of the input is read from
+func (i *Input) Source() TemperatureSource {
+	return i.config.Source
+}
+
+// Path returns the sysfs path of the input, only set for the sysfs source
+func (i *Input) Path() string {
+	return i.config.Path
+}
+
+// GetFanSpeedPercent returns the fan speed in percent for the temperature of this input.
+// Inputs without a fan curve (of the expression fan controller) always return 0%.
+func (i *Input) GetFanSpeedPercent(temperature float64) uint8 {
+	if i.curve == nil {
+		return 0
+	}
+	return i.curve.GetFanSpeedPercent(i.smoother.Temperature(temperature))
+}
+
+// Aggregate combines the fan speeds of multiple inputs
+func Aggregate(aggregation Aggregation, percents ...uint8) uint8 {
+	if len(percents) == 0 {
+		return 100
+	}
+
+	switch aggregation {
+	case AggregationAverage:
+		var sum int
+		for _, percent := range percents {
+			sum += int(percent)
+		}
+		return uint8(sum / len(percents))
+	default:
+		result := percents[0]
+		for _, percent := range percents[1:] {
+			result = max(result, percent)
+		}
+		return result
+	}
+}
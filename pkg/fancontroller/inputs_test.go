@@ -0,0 +1,154 @@
+package fancontroller_test
+
+import (
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInputs(t *testing.T) {
+	t.Parallel()
+
+	inputs, err := fancontroller.NewInputs(fancontroller.Config{
+		Inputs: []fancontroller.InputConfig{
+			{
+				Name:   "airflow",
+				Source: fancontroller.TemperatureSourceAirFlow,
+				Steps: []fancontroller.Step{
+					{Temperature: 30, Percent: 20},
+					{Temperature: 40, Percent: 100},
+				},
+			},
+			{
+				Name:   "nvme",
+				Source: fancontroller.TemperatureSourceSysfs,
+				Path:   "/sys/class/nvme/nvme0/hwmon*/temp1_input",
+				Steps: []fancontroller.Step{
+					{Temperature: 50, Percent: 30},
+					{Temperature: 70, Percent: 100},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create inputs: %v", err)
+	}
+
+	assert.Len(t, inputs, 2)
+	assert.Equal(t, "airflow", inputs[0].Name())
+	assert.Equal(t, fancontroller.TemperatureSourceAirFlow, inputs[0].Source())
+	assert.Equal(t, uint8(60), inputs[0].GetFanSpeedPercent(35))
+	assert.Equal(t, "nvme", inputs[1].Name())
+	assert.Equal(t, fancontroller.TemperatureSourceSysfs, inputs[1].Source())
+	assert.Equal(t, "/sys/class/nvme/nvme0/hwmon*/temp1_input", inputs[1].Path())
+	assert.Equal(t, uint8(100), inputs[1].GetFanSpeedPercent(80))
+}
+
+func TestNewInputs_Expression(t *testing.T) {
+	t.Parallel()
+
+	inputs, err := fancontroller.NewInputs(fancontroller.Config{
+		Type:   fancontroller.ControllerTypeExpression,
+		Inputs: []fancontroller.InputConfig{{Name: "airflow", Source: fancontroller.TemperatureSourceAirFlow}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create inputs: %v", err)
+	}
+
+	// Inputs of the expression fan controller have no fan curve
+	assert.Len(t, inputs, 1)
+	assert.Equal(t, "airflow", inputs[0].Name())
+	assert.Equal(t, uint8(0), inputs[0].GetFanSpeedPercent(80))
+}
+
+func TestNewInputs_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	steps := []fancontroller.Step{{Temperature: 40, Percent: 50}}
+
+	testCases := []struct {
+		name   string
+		config fancontroller.Config
+		errMsg string
+	}{
+		{
+			name:   "Unknown aggregation",
+			config: fancontroller.Config{Aggregation: "median"},
+			errMsg: `unknown fan speed aggregation "median"`,
+		},
+		{
+			name: "Missing name",
+			config: fancontroller.Config{Inputs: []fancontroller.InputConfig{
+				{Source: fancontroller.TemperatureSourceSoC, Steps: steps},
+			}},
+			errMsg: "fan controller input must have a name",
+		},
+		{
+			name: "Duplicate name",
+			config: fancontroller.Config{Inputs: []fancontroller.InputConfig{
+				{Name: "soc", Source: fancontroller.TemperatureSourceSoC, Steps: steps},
+				{Name: "soc", Source: fancontroller.TemperatureSourceAirFlow, Steps: steps},
+			}},
+			errMsg: `duplicate fan controller input "soc"`,
+		},
+		{
+			name: "Unknown source",
+			config: fancontroller.Config{Inputs: []fancontroller.InputConfig{
+				{Name: "gpu", Source: "gpu", Steps: steps},
+			}},
+			errMsg: `fan controller input "gpu" has unknown source "gpu"`,
+		},
+		{
+			name: "Sysfs without path",
+			config: fancontroller.Config{Inputs: []fancontroller.InputConfig{
+				{Name: "nvme", Source: fancontroller.TemperatureSourceSysfs, Steps: steps},
+			}},
+			errMsg: `fan controller input "nvme" has no path`,
+		},
+		{
+			name: "No steps",
+			config: fancontroller.Config{Inputs: []fancontroller.InputConfig{
+				{Name: "airflow", Source: fancontroller.TemperatureSourceAirFlow},
+			}},
+			errMsg: `fan controller input "airflow" has no steps`,
+		},
+		{
+			name: "Steps for the expression fan controller",
+			config: fancontroller.Config{Type: fancontroller.ControllerTypeExpression, Inputs: []fancontroller.InputConfig{
+				{Name: "airflow", Source: fancontroller.TemperatureSourceAirFlow, Steps: steps},
+			}},
+			errMsg: `fan controller input "airflow" has steps, which the expression fan controller does not use`,
+		},
+		{
+			name: "Invalid steps",
+			config: fancontroller.Config{Inputs: []fancontroller.InputConfig{
+				{Name: "airflow", Source: fancontroller.TemperatureSourceAirFlow, Steps: []fancontroller.Step{
+					{Temperature: 30, Percent: 80},
+					{Temperature: 40, Percent: 20},
+				}},
+			}},
+			errMsg: `invalid fan curve for input "airflow"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		config := tc.config
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := fancontroller.NewInputs(config)
+			assert.EqualError(t, err, expectedErrMsg)
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint8(80), fancontroller.Aggregate(fancontroller.AggregationMax, 40, 80, 20))
+	assert.Equal(t, uint8(80), fancontroller.Aggregate("", 40, 80, 20))
+	assert.Equal(t, uint8(46), fancontroller.Aggregate(fancontroller.AggregationAverage, 40, 80, 20))
+	assert.Equal(t, uint8(40), fancontroller.Aggregate(fancontroller.AggregationAverage, 40))
+	assert.Equal(t, uint8(100), fancontroller.Aggregate(fancontroller.AggregationMax))
+}
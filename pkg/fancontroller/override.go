@@ -0,0 +1,81 @@
+package fancontroller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+)
+
+// overrideState keeps track of the FanOverrideOpts of a FanController.
+// It is embedded into all FanController implementations so overrides behave the same regardless of the control logic.
+type overrideState struct {
+	overrideMu   sync.Mutex
+	overrideOpts *FanOverrideOpts
+	// overrideClock is used to expire overrides, the real clock is used if nil
+	overrideClock util.Clock
+}
+
+// Override sets (or with nil, clears) a fixed fan speed that takes precedence over the control logic
+func (o *overrideState) Override(opts *FanOverrideOpts) {
+	o.overrideMu.Lock()
+	defer o.overrideMu.Unlock()
+	o.overrideOpts = opts
+}
+
+// IsAutomaticSpeed returns true if no override is active
+func (o *overrideState) IsAutomaticSpeed() bool {
+	o.overrideMu.Lock()
+	defer o.overrideMu.Unlock()
+	return o.activeOverride() == nil
+}
+
+// ActiveOverride returns a copy of the active override, or nil if the fan speed is determined automatically
+func (o *overrideState) ActiveOverride() *FanOverrideOpts {
+	o.overrideMu.Lock()
+	defer o.overrideMu.Unlock()
+
+	opts := o.activeOverride()
+	if opts == nil {
+		return nil
+	}
+	optsCopy := *opts
+	return &optsCopy
+}
+
+// overridePercent returns the fan speed of the active override, and whether an override is active at all
+func (o *overrideState) overridePercent() (uint8, bool) {
+	o.overrideMu.Lock()
+	defer o.overrideMu.Unlock()
+
+	opts := o.activeOverride()
+	if opts == nil {
+		return 0, false
+	}
+	return opts.Percent, true
+}
+
+// activeOverride clears an expired override and returns the active one. overrideMu must be held.
+func (o *overrideState) activeOverride() *FanOverrideOpts {
+	if o.overrideOpts == nil || o.overrideOpts.ExpiresAt.IsZero() {
+		return o.overrideOpts
+	}
+
+	clock := o.overrideClock
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+	if !clock.Now().Before(o.overrideOpts.ExpiresAt) {
+		o.overrideOpts = nil
+	}
+
+	return o.overrideOpts
+}
+
+// Remaining returns the time left until the override expires, or 0 if it doesn't expire
+func (o *FanOverrideOpts) Remaining(now time.Time) time.Duration {
+	if o.ExpiresAt.IsZero() {
+		return 0
+	}
+	return max(o.ExpiresAt.Sub(now), 0)
+}
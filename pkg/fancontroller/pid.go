@@ -0,0 +1,134 @@
+package fancontroller
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// pidMinSampleInterval is the minimum time between two samples updating the controller state.
+// Samples arriving faster (e.g. status requests) return the last output without disturbing the integral and derivative terms.
+const pidMinSampleInterval = time.Second
+
+// fanControllerPID is a closed-loop fan controller trying to hold a target temperature
+type fanControllerPID struct {
+	overrideState
+
+	mu     sync.Mutex
+	config PIDConfig
+	clock  util.Clock
+
+	// integral is the accumulated integral term in percent
+	integral float64
+	// lastTemperature and lastSample hold the previous sample, lastSample is zero before the first sample
+	lastTemperature float64
+	lastSample      time.Time
+	// lastOutput is the previously computed fan speed
+	lastOutput uint8
+}
+
+// NewPIDFanController creates a new PID fan controller. If clock is nil, the real clock is used.
+func NewPIDFanController(config Config, clock util.Clock) (FanController, humane.Error) {
+	pid := config.PID
+	if pid.MaxPercent == 0 {
+		pid.MaxPercent = 100
+	}
+	if pid.IntegralLimit == 0 {
+		pid.IntegralLimit = float64(pid.MaxPercent)
+	}
+
+	if pid.TargetTemperature <= 0 {
+		return nil, humane.New("pid target temperature must be set",
+			"Set fan_controller.pid.target_temperature to the temperature the fan controller should hold",
+		)
+	}
+	if pid.Kp < 0 || pid.Ki < 0 || pid.Kd < 0 || pid.IntegralLimit < 0 {
+		return nil, humane.New("pid gains must not be negative",
+			fmt.Sprintf("Ensure kp (%.2f), ki (%.2f), kd (%.2f) and integral_limit (%.2f) are >= 0", pid.Kp, pid.Ki, pid.Kd, pid.IntegralLimit),
+		)
+	}
+	if pid.MaxPercent > 100 || pid.MinPercent > pid.MaxPercent {
+		return nil, humane.New("pid fan percent range is invalid",
+			fmt.Sprintf("Ensure 0 <= min_percent (%d) <= max_percent (%d) <= 100", pid.MinPercent, pid.MaxPercent),
+		)
+	}
+
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &fanControllerPID{
+		overrideState: overrideState{overrideClock: clock},
+		config:        pid,
+		clock:         clock,
+		lastOutput:    pid.MaxPercent,
+	}, nil
+}
+
+// Steps returns no steps, the PID controller does not use a fan curve
+func (f *fanControllerPID) Steps() []Step {
+	return nil
+}
+
+// SetSteps always fails, the PID controller does not use a fan curve
+func (f *fanControllerPID) SetSteps(_ []Step) humane.Error {
+	return humane.New("the pid fan controller does not use a fan curve",
+		"Switch fan_controller.type to linear to use a fan curve",
+	)
+}
+
+// GetFanSpeedPercent returns the fan speed in percent based on the deviation of the temperature from the target temperature
+func (f *fanControllerPID) GetFanSpeedPercent(temperature float64) uint8 {
+	if percent, ok := f.overridePercent(); ok {
+		return percent
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.clock.Now()
+	var dt float64
+	if !f.lastSample.IsZero() {
+		if now.Sub(f.lastSample) < pidMinSampleInterval {
+			return f.lastOutput
+		}
+		dt = now.Sub(f.lastSample).Seconds()
+	}
+
+	// A positive error means the blade is too hot and needs more airflow
+	err := temperature - f.config.TargetTemperature
+
+	var derivative float64
+	if dt > 0 {
+		derivative = (temperature - f.lastTemperature) / dt
+	}
+
+	// Integrate, bounded by the integral limit
+	prevIntegral := f.integral
+	f.integral = clamp(f.integral+f.config.Ki*err*dt, -f.config.IntegralLimit, f.config.IntegralLimit)
+
+	raw := f.config.Kp*err + f.integral + f.config.Kd*derivative
+	minPercent := float64(f.config.MinPercent)
+	maxPercent := float64(f.config.MaxPercent)
+
+	// Anti-windup: don't keep integrating while the output is saturated in the direction of the error
+	if (raw > maxPercent && err > 0) || (raw < minPercent && err < 0) {
+		f.integral = prevIntegral
+		raw = f.config.Kp*err + f.integral + f.config.Kd*derivative
+	}
+
+	f.lastTemperature = temperature
+	f.lastSample = now
+	f.lastOutput = uint8(math.Round(clamp(raw, minPercent, maxPercent)))
+
+	return f.lastOutput
+}
+
+// clamp limits value to [lower, upper]
+func clamp(value, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, value))
+}
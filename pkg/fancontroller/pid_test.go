@@ -0,0 +1,265 @@
+package fancontroller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// pidTestClock returns a mock clock returning the given number of timestamps, each interval apart
+func pidTestClock(samples int, interval time.Duration) *util.MockClock {
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	for i := 0; i < samples; i++ {
+		clk.On("Now").Once().Return(start.Add(time.Duration(i) * interval))
+	}
+	return clk
+}
+
+func TestFanControllerPID_HoldsTargetTemperature(t *testing.T) {
+	t.Parallel()
+
+	const samples = 400
+	const interval = 5 * time.Second
+	clk := pidTestClock(samples, interval)
+
+	controller, err := fancontroller.NewPIDFanController(fancontroller.Config{
+		Type: fancontroller.ControllerTypePID,
+		PID: fancontroller.PIDConfig{
+			TargetTemperature: 55,
+			Kp:                4,
+			Ki:                0.05,
+			MinPercent:        20,
+		},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Simple thermal model of a blade: constant heat load, cooling proportional to fan speed and temperature delta
+	const ambient = 25.0
+	const load = 10.0
+	temperature := 40.0
+	var speed uint8
+	for i := 0; i < samples; i++ {
+		speed = controller.GetFanSpeedPercent(temperature)
+		assert.GreaterOrEqual(t, speed, uint8(20))
+		assert.LessOrEqual(t, speed, uint8(100))
+
+		cooling := 0.5 * (float64(speed) / 100) * (temperature - ambient)
+		temperature += (load - cooling) * interval.Seconds() / 10
+	}
+
+	assert.InDelta(t, 55, temperature, 0.5, "expected the temperature to settle at the target")
+	assert.InDelta(t, 67, speed, 2, "expected the fan speed to settle at the equilibrium speed")
+	assert.True(t, controller.IsAutomaticSpeed())
+	clk.AssertExpectations(t)
+}
+
+func TestFanControllerPID_SyntheticSeries(t *testing.T) {
+	t.Parallel()
+
+	temperatures := []float64{40, 45, 50, 55, 60, 65, 65, 60, 55, 50}
+	clk := pidTestClock(len(temperatures), 5*time.Second)
+
+	controller, err := fancontroller.NewPIDFanController(fancontroller.Config{
+		PID: fancontroller.PIDConfig{
+			TargetTemperature: 55,
+			Kp:                5,
+			MinPercent:        10,
+			MaxPercent:        90,
+		},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Proportional only: output = clamp(5 * (temperature - 55), 10, 90)
+	expected := []uint8{10, 10, 10, 10, 25, 50, 50, 25, 10, 10}
+	for idx, temperature := range temperatures {
+		assert.Equal(t, expected[idx], controller.GetFanSpeedPercent(temperature), "sample %d (%.1f°C)", idx, temperature)
+	}
+	clk.AssertExpectations(t)
+}
+
+func TestFanControllerPID_AntiWindup(t *testing.T) {
+	t.Parallel()
+
+	clk := pidTestClock(62, 5*time.Second)
+
+	controller, err := fancontroller.NewPIDFanController(fancontroller.Config{
+		PID: fancontroller.PIDConfig{
+			TargetTemperature: 50,
+			Kp:                10,
+			Ki:                0.5,
+		},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	// Saturate the controller for a long time
+	for i := 0; i < 60; i++ {
+		assert.Equal(t, uint8(100), controller.GetFanSpeedPercent(70))
+	}
+
+	// Once the temperature drops below the target, the fan slows down right away instead of unwinding the integral
+	assert.Less(t, controller.GetFanSpeedPercent(45), uint8(100))
+	assert.Equal(t, uint8(0), controller.GetFanSpeedPercent(40))
+	clk.AssertExpectations(t)
+}
+
+func TestFanControllerPID_IgnoresFastSamples(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(start)
+	clk.On("Now").Once().Return(start.Add(100 * time.Millisecond))
+
+	controller, err := fancontroller.NewPIDFanController(fancontroller.Config{
+		PID: fancontroller.PIDConfig{
+			TargetTemperature: 50,
+			Kp:                2,
+			Kd:                100,
+		},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	assert.Equal(t, uint8(20), controller.GetFanSpeedPercent(60))
+	// A huge derivative would be computed for such a short interval, the previous output is returned instead
+	assert.Equal(t, uint8(20), controller.GetFanSpeedPercent(70))
+	clk.AssertExpectations(t)
+}
+
+func TestFanControllerPID_Override(t *testing.T) {
+	t.Parallel()
+
+	clk := pidTestClock(1, 5*time.Second)
+	controller, err := fancontroller.New(fancontroller.Config{
+		Type: fancontroller.ControllerTypePID,
+		PID: fancontroller.PIDConfig{
+			TargetTemperature: 50,
+			Kp:                2,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	controller.Override(&fancontroller.FanOverrideOpts{Percent: 42})
+	assert.False(t, controller.IsAutomaticSpeed())
+	assert.Equal(t, uint8(42), controller.GetFanSpeedPercent(20))
+	assert.Equal(t, uint8(42), controller.GetFanSpeedPercent(90))
+	assert.Empty(t, controller.Steps())
+
+	controller.Override(nil)
+	assert.True(t, controller.IsAutomaticSpeed())
+
+	// Overrides don't touch the clock, only automatic control does
+	pid, err := fancontroller.NewPIDFanController(fancontroller.Config{
+		PID: fancontroller.PIDConfig{TargetTemperature: 50, Kp: 2},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+	pid.Override(&fancontroller.FanOverrideOpts{Percent: 42})
+	assert.Equal(t, uint8(42), pid.GetFanSpeedPercent(90))
+	pid.Override(nil)
+	assert.Equal(t, uint8(80), pid.GetFanSpeedPercent(90))
+	clk.AssertExpectations(t)
+}
+
+func TestFanControllerPID_OverrideExpiry(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(start)
+	clk.On("Now").Once().Return(start.Add(9 * time.Minute))
+	clk.On("Now").Once().Return(start.Add(10 * time.Minute))
+	clk.On("Now").Once().Return(start.Add(10 * time.Minute))
+
+	controller, err := fancontroller.NewPIDFanController(fancontroller.Config{
+		PID: fancontroller.PIDConfig{TargetTemperature: 50, Kp: 2},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	lease := &fancontroller.FanOverrideOpts{Percent: 42, Owner: "bob", ExpiresAt: start.Add(10 * time.Minute)}
+	controller.Override(lease)
+	assert.Equal(t, uint8(42), controller.GetFanSpeedPercent(90))
+	assert.Equal(t, time.Minute, controller.ActiveOverride().Remaining(start.Add(9*time.Minute)))
+
+	// Once the lease expired, automatic control resumes
+	assert.True(t, controller.IsAutomaticSpeed())
+	assert.Equal(t, uint8(80), controller.GetFanSpeedPercent(90))
+	clk.AssertExpectations(t)
+
+	assert.Equal(t, time.Duration(0), lease.Remaining(start.Add(time.Hour)))
+	assert.Equal(t, time.Duration(0), (&fancontroller.FanOverrideOpts{Percent: 42}).Remaining(start))
+}
+
+func TestFanController_New(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config fancontroller.Config
+		errMsg string
+	}{
+		{
+			name: "Default is linear",
+			config: fancontroller.Config{
+				Steps: []fancontroller.Step{{Temperature: 40, Percent: 50}},
+			},
+		},
+		{
+			name:   "Unknown type",
+			config: fancontroller.Config{Type: "bang-bang"},
+			errMsg: `unknown fan controller type "bang-bang"`,
+		},
+		{
+			name:   "PID without target temperature",
+			config: fancontroller.Config{Type: fancontroller.ControllerTypePID},
+			errMsg: "pid target temperature must be set",
+		},
+		{
+			name: "PID with negative gains",
+			config: fancontroller.Config{
+				Type: fancontroller.ControllerTypePID,
+				PID:  fancontroller.PIDConfig{TargetTemperature: 50, Kp: -1},
+			},
+			errMsg: "pid gains must not be negative",
+		},
+		{
+			name: "PID with invalid range",
+			config: fancontroller.Config{
+				Type: fancontroller.ControllerTypePID,
+				PID:  fancontroller.PIDConfig{TargetTemperature: 50, MinPercent: 80, MaxPercent: 40},
+			},
+			errMsg: "pid fan percent range is invalid",
+		},
+	}
+
+	for _, tc := range testCases {
+		config := tc.config
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			controller, err := fancontroller.New(config, nil)
+			if expectedErrMsg == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, controller)
+				return
+			}
+			assert.EqualError(t, err, expectedErrMsg)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package fancontroller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// Profiles switches the fan curve of a FanController between named profiles and keeps track of the active one
+type Profiles struct {
+	mu         sync.Mutex
+	controller FanController
+	profiles   map[string]Profile
+	active     string
+}
+
+// NewProfiles validates the given profiles and returns a Profiles instance applying them to controller.
+// No profile is active initially.
+func NewProfiles(controller FanController, profiles map[string]Profile) (*Profiles, humane.Error) {
+	for name, profile := range profiles {
+		if len(profile.Steps) == 0 {
+			return nil, humane.New(fmt.Sprintf("fan profile %q has no steps", name),
+				"Define at least one temperature/speed step for every fan profile",
+			)
+		}
+		if _, err := validateSteps(profile.Steps); err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("invalid fan curve for profile %q", name))
+		}
+	}
+
+	return &Profiles{
+		controller: controller,
+		profiles:   profiles,
+	}, nil
+}
+
+// Activate applies the fan curve of the named profile and marks it as active
+func (p *Profiles) Activate(name string) humane.Error {
+	profile, ok := p.profiles[name]
+	if !ok {
+		return humane.New(fmt.Sprintf("unknown fan profile %q", name),
+			fmt.Sprintf("available profiles are: [%s]", strings.Join(p.Names(), ", ")),
+		)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.controller.SetSteps(profile.Steps); err != nil {
+		return err
+	}
+	p.active = name
+
+	return nil
+}
+
+// Active returns the name of the active profile, or an empty string if the fan curve was not set by a profile
+func (p *Profiles) Active() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.active
+}
+
+// SetSteps replaces the fan curve with a custom one, which deactivates the active profile
+func (p *Profiles) SetSteps(steps []Step) humane.Error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.controller.SetSteps(steps); err != nil {
+		return err
+	}
+	p.active = ""
+
+	return nil
+}
+
+// Names returns the sorted names of all profiles
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.profiles))
+	for name := range p.profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,96 @@
+package fancontroller_test
+
+import (
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfiles_Activate(t *testing.T) {
+	t.Parallel()
+
+	controller, err := fancontroller.NewLinearFanController(fancontroller.Config{
+		Steps: []fancontroller.Step{{Temperature: 40, Percent: 50}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create fan controller: %v", err)
+	}
+
+	profiles, err := fancontroller.NewProfiles(controller, map[string]fancontroller.Profile{
+		"quiet": {Steps: []fancontroller.Step{
+			{Temperature: 50, Percent: 20},
+			{Temperature: 60, Percent: 60},
+		}},
+		"performance": {Steps: []fancontroller.Step{
+			{Temperature: 40, Percent: 60},
+			{Temperature: 50, Percent: 100},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create fan profiles: %v", err)
+	}
+
+	assert.Equal(t, []string{"performance", "quiet"}, profiles.Names())
+	assert.Equal(t, "", profiles.Active())
+
+	assert.Nil(t, profiles.Activate("quiet"))
+	assert.Equal(t, "quiet", profiles.Active())
+	assert.Equal(t, uint8(20), controller.GetFanSpeedPercent(45))
+
+	assert.Nil(t, profiles.Activate("performance"))
+	assert.Equal(t, "performance", profiles.Active())
+	assert.Equal(t, uint8(80), controller.GetFanSpeedPercent(45))
+
+	// Unknown profiles keep the active profile
+	assert.EqualError(t, profiles.Activate("turbo"), `unknown fan profile "turbo"`)
+	assert.Equal(t, "performance", profiles.Active())
+
+	// A custom fan curve deactivates the profile
+	assert.Nil(t, profiles.SetSteps([]fancontroller.Step{{Temperature: 40, Percent: 30}}))
+	assert.Equal(t, "", profiles.Active())
+	assert.Equal(t, uint8(30), controller.GetFanSpeedPercent(45))
+
+	// Invalid fan curves keep the active profile
+	assert.Nil(t, profiles.Activate("quiet"))
+	assert.EqualError(t, profiles.SetSteps([]fancontroller.Step{{Temperature: 40, Percent: 130}}), "fan percent must be between 0 and 100")
+	assert.Equal(t, "quiet", profiles.Active())
+}
+
+func TestProfiles_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		profiles map[string]fancontroller.Profile
+		errMsg   string
+	}{
+		{
+			name:     "No steps",
+			profiles: map[string]fancontroller.Profile{"quiet": {}},
+			errMsg:   `fan profile "quiet" has no steps`,
+		},
+		{
+			name: "Invalid steps",
+			profiles: map[string]fancontroller.Profile{"quiet": {Steps: []fancontroller.Step{
+				{Temperature: 40, Percent: 80},
+				{Temperature: 50, Percent: 20},
+			}}},
+			errMsg: `invalid fan curve for profile "quiet"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		profiles := tc.profiles
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			controller, err := fancontroller.NewLinearFanController(fancontroller.Config{}, nil)
+			if err != nil {
+				t.Fatalf("Failed to create fan controller: %v", err)
+			}
+			_, err = fancontroller.NewProfiles(controller, profiles)
+			assert.EqualError(t, err, expectedErrMsg)
+		})
+	}
+}
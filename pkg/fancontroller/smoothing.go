@@ -0,0 +1,110 @@
+package fancontroller
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// Smoother dampens the fan control path to avoid audible hunting around curve steps.
+// Temperatures pass through a hysteresis band before they reach the controller, and the resulting fan speed is
+// slew-rate limited with separate rates for speeding up and slowing down.
+type Smoother struct {
+	mu     sync.Mutex
+	config Config
+	clock  util.Clock
+
+	// temperature is the temperature last passed on to the controller, NaN before the first sample
+	temperature float64
+	// percent is the last smoothed fan speed, NaN before the first sample
+	percent float64
+	// lastUpdate is the time percent was last updated
+	lastUpdate time.Time
+}
+
+// NewSmoother creates a new Smoother using the hysteresis and ramp rates of the config. If clock is nil, the real clock is used.
+func NewSmoother(config Config, clock util.Clock) (*Smoother, humane.Error) {
+	if config.Hysteresis < 0 || config.RampUpRate < 0 || config.RampDownRate < 0 {
+		return nil, humane.New("fan smoothing settings must not be negative",
+			fmt.Sprintf("Ensure hysteresis (%.2f), ramp_up_rate (%.2f) and ramp_down_rate (%.2f) are >= 0", config.Hysteresis, config.RampUpRate, config.RampDownRate),
+		)
+	}
+
+	// A rate of 0 is unlimited, so a limited ramp up is always slower than an unlimited ramp down
+	if config.RampUpRate > 0 && (config.RampDownRate == 0 || config.RampUpRate < config.RampDownRate) {
+		return nil, humane.New("fan ramp up rate must not be slower than ramp down rate",
+			"The fan has to be able to react faster to rising temperatures than to falling ones",
+			fmt.Sprintf("Ensure ramp_up_rate (%.2f) is >= ramp_down_rate (%.2f), or set ramp_up_rate to 0 for no limit", config.RampUpRate, config.RampDownRate),
+		)
+	}
+
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &Smoother{
+		config:      config,
+		clock:       clock,
+		temperature: math.NaN(),
+		percent:     math.NaN(),
+	}, nil
+}
+
+// Temperature applies the hysteresis to a temperature reading.
+// Rising temperatures are passed on immediately, falling temperatures only once they dropped by more than the hysteresis.
+func (s *Smoother) Temperature(temperature float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case math.IsNaN(s.temperature), temperature > s.temperature:
+		s.temperature = temperature
+	case temperature < s.temperature-s.config.Hysteresis:
+		s.temperature = temperature + s.config.Hysteresis
+	}
+
+	return s.temperature
+}
+
+// Percent moves the fan speed towards target, limited by the configured ramp rates
+func (s *Smoother) Percent(target uint8) uint8 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	defer func() {
+		s.lastUpdate = now
+	}()
+
+	if math.IsNaN(s.percent) {
+		s.percent = float64(target)
+		return target
+	}
+
+	elapsed := now.Sub(s.lastUpdate).Seconds()
+	delta := float64(target) - s.percent
+
+	if delta > 0 && s.config.RampUpRate > 0 {
+		delta = math.Min(delta, s.config.RampUpRate*elapsed)
+	}
+	if delta < 0 && s.config.RampDownRate > 0 {
+		delta = math.Max(delta, -s.config.RampDownRate*elapsed)
+	}
+
+	s.percent += delta
+	return uint8(math.Round(s.percent))
+}
+
+// Reset makes the smoother continue from a fan speed applied without it, e.g. while the fan speed was overridden.
+// Later fan speeds are ramped starting from percent.
+func (s *Smoother) Reset(percent uint8) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.percent = float64(percent)
+	s.lastUpdate = s.clock.Now()
+}
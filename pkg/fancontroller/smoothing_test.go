@@ -0,0 +1,164 @@
+package fancontroller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmoother_Temperature(t *testing.T) {
+	t.Parallel()
+
+	smoother, err := fancontroller.NewSmoother(fancontroller.Config{Hysteresis: 2}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create smoother: %v", err)
+	}
+
+	testCases := []struct {
+		temperature float64
+		expected    float64
+	}{
+		{50, 50},   // First sample is passed on
+		{52, 52},   // Rising temperatures are passed on immediately
+		{51, 52},   // Small drops are held back
+		{50.5, 52}, // ... as long as they stay within the hysteresis
+		{49, 51},   // Larger drops are passed on, lagging by the hysteresis
+		{50, 51},   // Rising within the hysteresis band is held back as well
+		{53, 53},   // Rising above the held temperature is passed on
+	}
+
+	for idx, tc := range testCases {
+		assert.Equal(t, tc.expected, smoother.Temperature(tc.temperature), "sample %d", idx)
+	}
+}
+
+func TestSmoother_TemperatureNoHysteresis(t *testing.T) {
+	t.Parallel()
+
+	smoother, err := fancontroller.NewSmoother(fancontroller.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create smoother: %v", err)
+	}
+
+	for _, temperature := range []float64{50, 45, 55, 54.9} {
+		assert.Equal(t, temperature, smoother.Temperature(temperature))
+	}
+}
+
+func TestSmoother_Percent(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	for i := 0; i < 7; i++ {
+		clk.On("Now").Once().Return(start.Add(time.Duration(i) * 5 * time.Second))
+	}
+
+	smoother, err := fancontroller.NewSmoother(fancontroller.Config{
+		RampUpRate:   4,
+		RampDownRate: 1,
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create smoother: %v", err)
+	}
+
+	testCases := []struct {
+		target   uint8
+		expected uint8
+	}{
+		{40, 40},  // First sample is applied directly
+		{100, 60}, // +4%/s for 5s
+		{100, 80},
+		{100, 100},
+		{40, 95}, // -1%/s for 5s
+		{40, 90},
+		{92, 92}, // Small increases are applied directly
+	}
+
+	for idx, tc := range testCases {
+		assert.Equal(t, tc.expected, smoother.Percent(tc.target), "sample %d", idx)
+	}
+	clk.AssertExpectations(t)
+}
+
+func TestSmoother_PercentUnlimited(t *testing.T) {
+	t.Parallel()
+
+	smoother, err := fancontroller.NewSmoother(fancontroller.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create smoother: %v", err)
+	}
+
+	for _, target := range []uint8{40, 100, 0, 55} {
+		assert.Equal(t, target, smoother.Percent(target))
+	}
+}
+
+func TestSmoother_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config fancontroller.Config
+		errMsg string
+	}{
+		{
+			name:   "Negative hysteresis",
+			config: fancontroller.Config{Hysteresis: -1},
+			errMsg: "fan smoothing settings must not be negative",
+		},
+		{
+			name:   "Negative ramp rate",
+			config: fancontroller.Config{RampDownRate: -1},
+			errMsg: "fan smoothing settings must not be negative",
+		},
+		{
+			name:   "Ramp up slower than ramp down",
+			config: fancontroller.Config{RampUpRate: 1, RampDownRate: 2},
+			errMsg: "fan ramp up rate must not be slower than ramp down rate",
+		},
+		{
+			name:   "Ramp up limited, ramp down unlimited",
+			config: fancontroller.Config{RampUpRate: 1},
+			errMsg: "fan ramp up rate must not be slower than ramp down rate",
+		},
+	}
+
+	for _, tc := range testCases {
+		config := tc.config
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := fancontroller.NewSmoother(config, nil)
+			assert.EqualError(t, err, expectedErrMsg)
+		})
+	}
+}
+
+func TestSmoother_Reset(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(start)
+	clk.On("Now").Once().Return(start.Add(5 * time.Second))
+	clk.On("Now").Once().Return(start.Add(10 * time.Second))
+
+	smoother, err := fancontroller.NewSmoother(fancontroller.Config{
+		RampUpRate:   4,
+		RampDownRate: 1,
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create smoother: %v", err)
+	}
+
+	assert.Equal(t, uint8(40), smoother.Percent(40))
+
+	// The fan ran at 100% without the smoother, e.g. in critical mode, and ramps down from there
+	smoother.Reset(100)
+	assert.Equal(t, uint8(95), smoother.Percent(40))
+	clk.AssertExpectations(t)
+}
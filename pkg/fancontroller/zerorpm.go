@@ -0,0 +1,72 @@
+package fancontroller
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// defaultFanOffHysteresis is the temperature rise (in °C) above the fan-off temperature required to restart the fan
+const defaultFanOffHysteresis = 3
+
+// ZeroRPM stops the fan completely at low temperatures. The fan stops once the temperature drops below the
+// fan-off temperature, and only restarts once the temperature rose above the fan-off temperature plus the hysteresis.
+type ZeroRPM struct {
+	mu             sync.Mutex
+	offTemperature float64
+	hysteresis     float64
+
+	// stopped indicates whether the fan is currently stopped
+	stopped bool
+}
+
+// NewZeroRPM creates a new ZeroRPM using the fan-off temperature and hysteresis of the config.
+// A fan-off temperature of 0 disables zero RPM mode.
+func NewZeroRPM(config Config) (*ZeroRPM, humane.Error) {
+	if config.FanOffTemperature < 0 || config.FanOffHysteresis < 0 {
+		return nil, humane.New("fan-off settings must not be negative",
+			fmt.Sprintf("Ensure fan_off_temperature (%.2f) and fan_off_hysteresis (%.2f) are >= 0", config.FanOffTemperature, config.FanOffHysteresis),
+		)
+	}
+
+	hysteresis := config.FanOffHysteresis
+	if hysteresis == 0 {
+		hysteresis = defaultFanOffHysteresis
+	}
+
+	return &ZeroRPM{
+		offTemperature: config.FanOffTemperature,
+		hysteresis:     hysteresis,
+	}, nil
+}
+
+// Percent returns 0 while the fan is stopped at the given temperature, percent otherwise
+func (z *ZeroRPM) Percent(temperature float64, percent uint8) uint8 {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	if z.offTemperature == 0 {
+		return percent
+	}
+
+	switch {
+	case temperature < z.offTemperature:
+		z.stopped = true
+	case temperature >= z.offTemperature+z.hysteresis:
+		z.stopped = false
+	}
+
+	if z.stopped {
+		return 0
+	}
+	return percent
+}
+
+// Stopped returns whether the fan is currently stopped
+func (z *ZeroRPM) Stopped() bool {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	return z.stopped
+}
@@ -0,0 +1,66 @@
+package fancontroller_test
+
+import (
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZeroRPM_Percent(t *testing.T) {
+	t.Parallel()
+
+	zeroRPM, err := fancontroller.NewZeroRPM(fancontroller.Config{FanOffTemperature: 40, FanOffHysteresis: 5})
+	if err != nil {
+		t.Fatalf("Failed to create zero RPM mode: %v", err)
+	}
+
+	testCases := []struct {
+		temperature float64
+		expected    uint8
+	}{
+		{42, 30}, // Above the fan-off temperature, the fan runs
+		{39, 0},  // Below the fan-off temperature, the fan stops
+		{43, 0},  // Rising within the hysteresis band keeps the fan stopped
+		{45, 30}, // Rising above the hysteresis band restarts the fan
+		{41, 30}, // Dropping within the hysteresis band keeps the fan running
+		{30, 0},
+	}
+
+	for idx, tc := range testCases {
+		assert.Equal(t, tc.expected, zeroRPM.Percent(tc.temperature, 30), "sample %d", idx)
+		assert.Equal(t, tc.expected == 0, zeroRPM.Stopped(), "sample %d", idx)
+	}
+}
+
+func TestZeroRPM_DefaultHysteresis(t *testing.T) {
+	t.Parallel()
+
+	zeroRPM, err := fancontroller.NewZeroRPM(fancontroller.Config{FanOffTemperature: 40})
+	if err != nil {
+		t.Fatalf("Failed to create zero RPM mode: %v", err)
+	}
+
+	assert.Equal(t, uint8(0), zeroRPM.Percent(35, 30))
+	assert.Equal(t, uint8(0), zeroRPM.Percent(42.9, 30))
+	assert.Equal(t, uint8(30), zeroRPM.Percent(43, 30))
+}
+
+func TestZeroRPM_Disabled(t *testing.T) {
+	t.Parallel()
+
+	zeroRPM, err := fancontroller.NewZeroRPM(fancontroller.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create zero RPM mode: %v", err)
+	}
+
+	assert.Equal(t, uint8(30), zeroRPM.Percent(20, 30))
+	assert.False(t, zeroRPM.Stopped())
+}
+
+func TestZeroRPM_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := fancontroller.NewZeroRPM(fancontroller.Config{FanOffTemperature: 40, FanOffHysteresis: -1})
+	assert.EqualError(t, err, "fan-off settings must not be negative")
+}
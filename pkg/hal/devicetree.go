@@ -0,0 +1,83 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultDeviceTreeRoot is where the kernel exposes the device tree of the board
+const DefaultDeviceTreeRoot = "/proc/device-tree"
+
+// computeModules maps device tree compatible strings to the short name of the compute module
+var computeModules = map[string]string{
+	"raspberrypi,3-compute-module": "cm3",
+	"raspberrypi,4-compute-module": "cm4",
+	"raspberrypi,5-compute-module": "cm5",
+}
+
+// DeviceTree describes the board the agent runs on, as read from the device tree
+type DeviceTree struct {
+	// Model is the human-readable board model, e.g. "Raspberry Pi Compute Module 4 Rev 1.1"
+	Model string
+	// Compatible lists the boards and SoCs the board is compatible with, most specific first,
+	// e.g. "raspberrypi,4-compute-module" and "brcm,bcm2711"
+	Compatible []string
+}
+
+// ReadDeviceTree reads the model and compatible strings of the device tree at root
+func ReadDeviceTree(root string) (DeviceTree, error) {
+	model, err := readDeviceTreeStrings(filepath.Join(root, "model"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return DeviceTree{}, err
+	}
+	compatible, err := readDeviceTreeStrings(filepath.Join(root, "compatible"))
+	if err != nil {
+		return DeviceTree{}, err
+	}
+
+	return DeviceTree{
+		Model:      strings.Join(model, " "),
+		Compatible: compatible,
+	}, nil
+}
+
+// readDeviceTreeStrings reads a device tree property holding a list of NUL-terminated strings
+func readDeviceTreeStrings(path string) ([]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, value := range strings.Split(string(raw), "\x00") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// IsCompatible returns whether the board is compatible with any of the given compatible strings
+func (dt DeviceTree) IsCompatible(compatible ...string) bool {
+	for _, c := range compatible {
+		if slices.Contains(dt.Compatible, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeModule returns the short name of the compute module (e.g. cm4), or an empty string if it is unknown
+func (dt DeviceTree) ComputeModule() string {
+	for _, compatible := range dt.Compatible {
+		if name, ok := computeModules[compatible]; ok {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+//go:build linux && !tinygo
+
+package hal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDriver_Linux(t *testing.T) {
+	t.Parallel()
+
+	// The CM4 and other BCM2711 based compute modules
+	driver, dt, err := hal.DetectDriver(fakeDeviceTree(t, "Raspberry Pi Compute Module 4 Rev 1.1", "raspberrypi,4-compute-module", "brcm,bcm2711"))
+	assert.NoError(t, err)
+	assert.Equal(t, hal.DriverBcm2711, driver.Name)
+	assert.Equal(t, "cm4", dt.ComputeModule())
+
+	// The CM5 is controlled through kernel interfaces
+	driver, dt, err = hal.DetectDriver(fakeDeviceTree(t, "Raspberry Pi Compute Module 5 Rev 1.0", "raspberrypi,5-compute-module", "brcm,bcm2712"))
+	assert.NoError(t, err)
+	assert.Equal(t, hal.DriverSysfs, driver.Name)
+	assert.Equal(t, "cm5", dt.ComputeModule())
+
+	// Unsupported boards aren't detected
+	_, _, err = hal.DetectDriver(fakeDeviceTree(t, "Radxa CM3 IO", "radxa,cm3-io", "rockchip,rk3566"))
+	assert.ErrorContains(t, err, `no HAL driver supports the board "Radxa CM3 IO"`)
+
+	_, _, err = hal.DetectDriver(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to read the device tree to detect the HAL driver")
+}
+
+func TestResolveDriver_Linux(t *testing.T) {
+	t.Parallel()
+
+	driver, err := hal.ResolveDriver(context.Background(), hal.ComputeBladeHalOpts{
+		DeviceTreeRoot: fakeDeviceTree(t, "Raspberry Pi Compute Module 5 Rev 1.0", "raspberrypi,5-compute-module", "brcm,bcm2712"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, hal.DriverSysfs, driver.Name)
+
+	// A board no driver supports is rejected instead of driving the registers of another SoC
+	_, err = hal.ResolveDriver(context.Background(), hal.ComputeBladeHalOpts{
+		DeviceTreeRoot: fakeDeviceTree(t, "Radxa CM3 IO", "radxa,cm3-io", "rockchip,rk3566"),
+	})
+	assert.ErrorContains(t, err, `no HAL driver supports the board "Radxa CM3 IO"`)
+
+	// Without a device tree, the agent falls back to the CM4 driver like before auto-detection
+
+	driver, err = hal.ResolveDriver(context.Background(), hal.ComputeBladeHalOpts{
+		Driver:         hal.DriverAuto,
+		DeviceTreeRoot: filepath.Join(t.TempDir(), "missing"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, hal.DriverBcm2711, driver.Name)
+}
@@ -0,0 +1,129 @@
+//go:build !tinygo
+
+package hal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDeviceTree writes a device tree with the given model and compatible strings and returns its root
+func fakeDeviceTree(t *testing.T, model string, compatible ...string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "model"), []byte(model+"\x00"), 0o444))
+
+	var raw []byte
+	for _, c := range compatible {
+		raw = append(raw, c...)
+		raw = append(raw, 0)
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "compatible"), raw, 0o444))
+	return root
+}
+
+func TestReadDeviceTree(t *testing.T) {
+	t.Parallel()
+
+	root := fakeDeviceTree(t, "Raspberry Pi Compute Module 4 Rev 1.1", "raspberrypi,4-compute-module", "brcm,bcm2711")
+	dt, err := hal.ReadDeviceTree(root)
+	assert.NoError(t, err)
+	assert.Equal(t, hal.DeviceTree{
+		Model:      "Raspberry Pi Compute Module 4 Rev 1.1",
+		Compatible: []string{"raspberrypi,4-compute-module", "brcm,bcm2711"},
+	}, dt)
+	assert.Equal(t, "cm4", dt.ComputeModule())
+	assert.True(t, dt.IsCompatible("brcm,bcm2712", "brcm,bcm2711"))
+	assert.False(t, dt.IsCompatible("brcm,bcm2712"))
+
+	// Other boards are detected, but have no known compute module
+	dt, err = hal.ReadDeviceTree(fakeDeviceTree(t, "Raspberry Pi 4 Model B Rev 1.5", "raspberrypi,4-model-b", "brcm,bcm2711"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", dt.ComputeModule())
+
+	// Without a device tree, the board can't be detected
+	_, err = hal.ReadDeviceTree(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// testBladeHal is returned by the test driver
+type testBladeHal struct {
+	hal.ComputeBladeHalMock
+}
+
+func init() {
+	hal.RegisterDriver(hal.Driver{
+		Name: "test",
+		Detect: func(dt hal.DeviceTree) bool {
+			return dt.IsCompatible("test,blade")
+		},
+		New: func(_ context.Context, _ hal.ComputeBladeHalOpts) (hal.ComputeBladeHal, error) {
+			return &testBladeHal{}, nil
+		},
+	})
+}
+
+func TestDrivers(t *testing.T) {
+	t.Parallel()
+
+	assert.Subset(t, hal.Drivers(), []string{"simulated", "test"})
+	assert.Panics(t, func() {
+		hal.RegisterDriver(hal.Driver{Name: "test", New: hal.New})
+	})
+}
+
+func TestDetectDriver(t *testing.T) {
+	t.Parallel()
+
+	driver, dt, err := hal.DetectDriver(fakeDeviceTree(t, "Test Blade", "test,blade", "test,soc"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test", driver.Name)
+	assert.Equal(t, "Test Blade", dt.Model)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	// Auto-detection
+	blade, err := hal.New(context.Background(), hal.ComputeBladeHalOpts{
+		DeviceTreeRoot: fakeDeviceTree(t, "Test Blade", "test,blade"),
+	})
+	assert.NoError(t, err)
+	assert.IsType(t, &testBladeHal{}, blade)
+
+	// Selected driver, regardless of the board
+	blade, err = hal.New(context.Background(), hal.ComputeBladeHalOpts{
+		Driver:         hal.DriverSimulated,
+		DeviceTreeRoot: fakeDeviceTree(t, "Test Blade", "test,blade"),
+	})
+	assert.NoError(t, err)
+	assert.IsType(t, &hal.SimulatedHal{}, blade)
+	assert.Equal(t, "simulated", blade.ComputeModule())
+
+	_, err = hal.New(context.Background(), hal.ComputeBladeHalOpts{Driver: "unknown"})
+	assert.ErrorContains(t, err, `unknown HAL driver "unknown"`)
+}
+
+func TestResolveDriver(t *testing.T) {
+	t.Parallel()
+
+	driver, err := hal.ResolveDriver(context.Background(), hal.ComputeBladeHalOpts{
+		DeviceTreeRoot: fakeDeviceTree(t, "Test Blade", "test,blade"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "test", driver.Name)
+
+	// A selected driver is never detected
+	driver, err = hal.ResolveDriver(context.Background(), hal.ComputeBladeHalOpts{
+		Driver:         hal.DriverSimulated,
+		DeviceTreeRoot: fakeDeviceTree(t, "Test Blade", "test,blade"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, hal.DriverSimulated, driver.Name)
+}
@@ -0,0 +1,164 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/sierrasoftworks/humane-errors-go"
+	"go.uber.org/zap"
+)
+
+const (
+	// DriverAuto selects the driver supporting the board, as detected from the device tree
+	DriverAuto = "auto"
+	// DriverBcm2711 drives the hardware of a compute blade with a CM4 (or compatible) compute module
+	DriverBcm2711 = "bcm2711"
+	// DriverSimulated simulates a compute blade as scripted by a Scenario
+	DriverSimulated = "simulated"
+)
+
+// Driver creates the HAL for the hardware it supports
+type Driver struct {
+	// Name selects the driver with hal.driver
+	Name string
+	// Detect returns whether the driver supports the board. Drivers without Detect are never auto-detected.
+	Detect func(dt DeviceTree) bool
+	// New creates the HAL
+	New func(ctx context.Context, opts ComputeBladeHalOpts) (ComputeBladeHal, error)
+}
+
+var (
+	driversMu sync.RWMutex
+	// drivers are the registered drivers, in the order they are tried during auto-detection
+	drivers []Driver
+)
+
+// RegisterDriver makes a driver available by its name. It panics if a driver with the same name is registered already.
+func RegisterDriver(driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if driver.New == nil {
+		panic("hal: driver " + driver.Name + " can't create a HAL")
+	}
+	if slices.ContainsFunc(drivers, func(d Driver) bool { return d.Name == driver.Name }) {
+		panic("hal: driver " + driver.Name + " registered twice")
+	}
+	drivers = append(drivers, driver)
+}
+
+// Drivers returns the names of the registered drivers
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for _, driver := range drivers {
+		names = append(names, driver.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// lookupDriver returns the driver registered with the given name
+func lookupDriver(name string) (Driver, humane.Error) {
+	driversMu.RLock()
+	idx := slices.IndexFunc(drivers, func(d Driver) bool { return d.Name == name })
+	var driver Driver
+	if idx >= 0 {
+		driver = drivers[idx]
+	}
+	driversMu.RUnlock()
+
+	if idx < 0 {
+		return Driver{}, humane.New(fmt.Sprintf("unknown HAL driver %q", name),
+			fmt.Sprintf("use one of %s, %s", DriverAuto, strings.Join(Drivers(), ", ")),
+		)
+	}
+	return driver, nil
+}
+
+// DetectDriver returns the driver supporting the board described by the device tree at root
+func DetectDriver(root string) (Driver, DeviceTree, humane.Error) {
+	dt, err := ReadDeviceTree(root)
+	if err != nil {
+		return Driver{}, dt, humane.Wrap(err, "failed to read the device tree to detect the HAL driver",
+			fmt.Sprintf("ensure %s is readable or set hal.driver to one of %s", root, strings.Join(Drivers(), ", ")),
+		)
+	}
+
+	driver, herr := detectDriver(dt)
+	return driver, dt, herr
+}
+
+// detectDriver returns the first registered driver supporting the board described by the device tree
+func detectDriver(dt DeviceTree) (Driver, humane.Error) {
+	driversMu.RLock()
+	idx := slices.IndexFunc(drivers, func(d Driver) bool { return d.Detect != nil && d.Detect(dt) })
+	var driver Driver
+	if idx >= 0 {
+		driver = drivers[idx]
+	}
+	driversMu.RUnlock()
+	if idx < 0 {
+		return Driver{}, humane.New(fmt.Sprintf("no HAL driver supports the board %q", dt.Model),
+			fmt.Sprintf("set hal.driver to one of %s", strings.Join(Drivers(), ", ")),
+		)
+	}
+	return driver, nil
+}
+
+// ResolveDriver returns the driver selected by opts.Driver. If none or auto is selected, the driver is detected from
+// the device tree. If the device tree can't be read, a warning is logged and the fallback driver of the platform is
+// used. A board that no driver supports is rejected, as the fallback driver would drive the hardware of another SoC.
+func ResolveDriver(ctx context.Context, opts ComputeBladeHalOpts) (Driver, humane.Error) {
+	if opts.Driver != "" && opts.Driver != DriverAuto {
+		return lookupDriver(opts.Driver)
+	}
+
+	root := opts.deviceTreeRoot()
+	dt, err := ReadDeviceTree(root)
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to read the device tree to detect the HAL driver, using the fallback driver",
+			zap.Error(err),
+			zap.String("device_tree_root", root),
+			zap.String("driver", fallbackDriver),
+		)
+		return lookupDriver(fallbackDriver)
+	}
+
+	driver, herr := detectDriver(dt)
+	if herr != nil {
+		return Driver{}, herr
+	}
+
+	log.FromContext(ctx).Info("Detected HAL driver",
+		zap.String("driver", driver.Name),
+		zap.String("model", dt.Model),
+		zap.Strings("compatible", dt.Compatible),
+	)
+	return driver, nil
+}
+
+// New creates the HAL of the driver selected by opts.Driver, see ResolveDriver
+func New(ctx context.Context, opts ComputeBladeHalOpts) (ComputeBladeHal, error) {
+	driver, err := ResolveDriver(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return driver.New(ctx, opts)
+}
+
+// deviceTreeRoot returns the device tree root configured in opts, or the default root
+func (opts ComputeBladeHalOpts) deviceTreeRoot() string {
+	if opts.DeviceTreeRoot != "" {
+		return opts.DeviceTreeRoot
+	}
+	return DefaultDeviceTreeRoot
+}
@@ -2,6 +2,7 @@ package hal
 
 import (
 	"context"
+	"time"
 
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
 )
@@ -41,6 +42,58 @@ const (
 
 type ComputeBladeHalOpts struct {
 	RpmReportingStandardFanUnit bool `mapstructure:"rpm_reporting_standard_fan_unit"`
+	// FanSpinUpKick is the time a standing fan is driven at full duty before a lower fan speed is applied, 0 disables the kick
+	FanSpinUpKick time.Duration `mapstructure:"fan_spin_up_kick"`
+	// LedBrightness scales the brightness of all LED colors in percent. Defaults to 100%.
+	LedBrightness uint8 `mapstructure:"led_brightness"`
+	// LedGamma is the gamma correction applied to LED colors, so color steps are perceived evenly. Defaults to 1 (off).
+	LedGamma float64 `mapstructure:"led_gamma"`
+	// Driver selects the HAL implementation by name. Empty or auto detects the driver from the device tree.
+	Driver string `mapstructure:"driver"`
+	// DeviceTreeRoot is the device tree used to detect the board, defaults to /proc/device-tree
+	DeviceTreeRoot string `mapstructure:"device_tree_root"`
+	// SimulatedScenario is a YAML file scripting the hardware simulated by the simulated driver.
+	// Without a scenario, the simulated blade idles at 42°C.
+	SimulatedScenario string `mapstructure:"simulated_scenario"`
+	// Sysfs configures the sysfs driver
+	Sysfs SysfsHalOpts `mapstructure:"sysfs"`
+}
+
+// SysfsHalOpts configures the sysfs driver, which controls the blade through kernel interfaces only
+type SysfsHalOpts struct {
+	// PwmChip is the sysfs PWM chip driving the fan, defaults to /sys/class/pwm/pwmchip0
+	PwmChip string `mapstructure:"pwm_chip"`
+	// PwmChannel is the channel of PwmChip driving the fan
+	PwmChannel uint `mapstructure:"pwm_channel"`
+	// PwmFrequency is the frequency of the fan PWM signal in Hz, defaults to 25kHz
+	PwmFrequency uint `mapstructure:"pwm_frequency"`
+	// FanTachometer is the hwmon fan*_input reporting the fan speed (may contain glob patterns).
+	// If empty, the fan speed isn't reported.
+	FanTachometer string `mapstructure:"fan_tachometer"`
+	// Temperature is the thermal zone or hwmon temp*_input of the SoC, defaults to /sys/class/thermal/thermal_zone0/temp
+	Temperature string `mapstructure:"temperature"`
+	// GpioChip is the GPIO chip of the button, stealth mode and PoE detection lines, defaults to gpiochip0
+	GpioChip string `mapstructure:"gpio_chip"`
+	// EdgeButtonLine is the GPIO line of the edge button, defaults to 20 if unset
+	EdgeButtonLine *int `mapstructure:"edge_button_line"`
+	// StealthModeLine is the GPIO line of the stealth mode output, defaults to 21 if unset
+	StealthModeLine *int `mapstructure:"stealth_mode_line"`
+	// PoeLine is the GPIO line of the PoE detection input, defaults to 23 if unset
+	PoeLine *int `mapstructure:"poe_line"`
+	// SmartFanUnitDevice is the UART of the smart fan unit. If empty, a standard fan unit is assumed.
+	SmartFanUnitDevice string `mapstructure:"smart_fan_unit_device"`
+	// Leds configures how the top and edge LED are driven
+	Leds LedBackendOpts `mapstructure:"leds"`
+}
+
+// LedBackendOpts configures the LED backend of HALs that don't drive the LEDs themselves
+type LedBackendOpts struct {
+	// Backend is none (default) or sysfs
+	Backend string `mapstructure:"backend"`
+	// Top is the sysfs multicolor LED of the top LED, e.g. /sys/class/leds/rgb:top
+	Top string `mapstructure:"top"`
+	// Edge is the sysfs multicolor LED of the edge LED
+	Edge string `mapstructure:"edge"`
 }
 
 // ComputeBladeHal abstracts hardware details of the Compute Blade and provides a simple interface
@@ -51,8 +104,12 @@ type ComputeBladeHal interface {
 	Close() error
 	// SetFanSpeed sets the fan speed in percent
 	SetFanSpeed(speed uint8) error
+	// SetFanSpinUpKick enables/disables the spin-up kick of a standing fan (see FanSpinUpKick), noop if it isn't configured
+	SetFanSpinUpKick(enabled bool) error
 	// GetFanRPM returns the current fan speed in percent (based on moving average)
 	GetFanRPM() (float64, error)
+	// GetFanUnitKind returns the kind of the detected fan unit
+	GetFanUnitKind() FanUnitKind
 	// SetStealthMode enables/disables stealth mode of the blade (turning on/off the LEDs)
 	SetStealthMode(enabled bool) error
 	// StealthModeActive returns if stealth mode of the blade is currently active
@@ -63,8 +120,12 @@ type ComputeBladeHal interface {
 	GetPowerStatus() (PowerStatus, error)
 	// GetTemperature returns the current temperature of the SoC in °C
 	GetTemperature() (float64, error)
+	// GetAirFlowTemperature returns the current temperature of the air flow in °C, if the fan unit provides a sensor
+	GetAirFlowTemperature() (float64, error)
 	// WaitForEdgeButtonPress returns a channel emitting edge button press events
 	WaitForEdgeButtonPress(ctx context.Context) error
+	// ComputeModule returns the model of the compute module, e.g. "Raspberry Pi Compute Module 4 Rev 1.1"
+	ComputeModule() string
 }
 
 // FanUnit abstracts the fan unit
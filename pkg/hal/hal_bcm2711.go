@@ -6,10 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
-	"strconv"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -61,6 +58,20 @@ const (
 	smartFanUnitDev = "/dev/ttyAMA5" // UART5
 )
 
+// fallbackDriver is used if the driver can't be detected, e.g. on older kernels without a device tree in /proc.
+// The agent always supported the CM4 on Linux.
+const fallbackDriver = DriverBcm2711
+
+func init() {
+	RegisterDriver(Driver{
+		Name: DriverBcm2711,
+		Detect: func(dt DeviceTree) bool {
+			return dt.IsCompatible("brcm,bcm2711")
+		},
+		New: NewCm4Hal,
+	})
+}
+
 type bcm2711 struct {
 	// Config options
 	opts ComputeBladeHalOpts
@@ -95,6 +106,9 @@ type bcm2711 struct {
 
 	// Fan unit
 	fanUnit FanUnit
+
+	// Model of the compute module, as read from the device tree
+	model string
 }
 
 func NewCm4Hal(ctx context.Context, opts ComputeBladeHalOpts) (ComputeBladeHal, error) {
@@ -137,7 +151,20 @@ func NewCm4Hal(ctx context.Context, opts ComputeBladeHalOpts) (ComputeBladeHal,
 		edgeButtonWatchChan:    make(chan struct{}),
 	}
 
-	computeModule.WithLabelValues("cm4").Set(1)
+	// The device tree only describes the compute module, the HAL works without it
+	dt, err := ReadDeviceTree(opts.deviceTreeRoot())
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to read device tree", zap.Error(err))
+	}
+	moduleName := dt.ComputeModule()
+	if moduleName == "" {
+		moduleName = "cm4"
+	}
+	bcm.model = dt.Model
+	if bcm.model == "" {
+		bcm.model = moduleName
+	}
+	computeModule.WithLabelValues(moduleName).Set(1)
 
 	log.FromContext(ctx).Info("starting hal setup", zap.String("hal", "bcm2711"))
 	err = bcm.setup(ctx)
@@ -213,6 +240,10 @@ func (bcm *bcm2711) setup(ctx context.Context) error {
 		}
 	}
 
+	if bcm.opts.FanSpinUpKick > 0 {
+		bcm.fanUnit = NewSpinUpKickFanUnit(bcm.fanUnit, bcm.opts.FanSpinUpKick, nil)
+	}
+
 	return nil
 }
 
@@ -280,6 +311,10 @@ func (bcm *bcm2711) GetFanRPM() (float64, error) {
 	return float64(rpm), err
 }
 
+func (bcm *bcm2711) GetFanUnitKind() FanUnitKind {
+	return bcm.fanUnit.Kind()
+}
+
 func (bcm *bcm2711) GetPowerStatus() (PowerStatus, error) {
 	// GPIO 23 is used for PoE detection
 	val, err := bcm.poeLine.Value()
@@ -342,6 +377,11 @@ func (bcm *bcm2711) SetFanSpeed(speed uint8) error {
 	return bcm.fanUnit.SetFanSpeedPercent(context.TODO(), speed)
 }
 
+// SetFanSpinUpKick enables or disables the spin-up kick of a standing fan
+func (bcm *bcm2711) SetFanSpinUpKick(enabled bool) error {
+	return setFanUnitSpinUpKick(bcm.fanUnit, enabled)
+}
+
 func (bcm *bcm2711) setFanSpeedPWM(speed uint8) {
 	// Noctua fans are expecting a 25khz signal, where duty cycle controls fan on/speed/off
 	// With the usage of the FIFO, we can alter the duty cycle by the number of bits set in the FIFO, maximum of 32.
@@ -486,24 +526,22 @@ func (bcm *bcm2711) updateLEDs() error {
 
 // GetTemperature returns the current temperature of the SoC
 func (bcm *bcm2711) GetTemperature() (float64, error) {
-	// Read temperature
-
-	f, err := os.Open(bcm2711ThermalZonePath)
-	if err != nil {
-		return -1, err
-	}
-	raw, err := io.ReadAll(f)
+	temp, err := ReadSysfsTemperature(bcm2711ThermalZonePath)
 	if err != nil {
 		return -1, err
 	}
 
-	cpuTemp, err := strconv.Atoi(strings.TrimSpace(string(raw)))
-	if err != nil {
-		return -1, err
-	}
-
-	temp := float64(cpuTemp) / 1000.0
 	socTemperature.Set(temp)
 
 	return temp, nil
 }
+
+// GetAirFlowTemperature returns the current temperature of the air flow as reported by the fan unit
+func (bcm *bcm2711) GetAirFlowTemperature() (float64, error) {
+	temp, err := bcm.fanUnit.AirFlowTemperature(context.TODO())
+	return float64(temp), err
+}
+
+func (bcm *bcm2711) ComputeModule() string {
+	return bcm.model
+}
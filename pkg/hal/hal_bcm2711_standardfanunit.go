@@ -5,6 +5,8 @@ package hal
 import (
 	"context"
 	"math"
+	"sync"
+	"time"
 
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
@@ -12,6 +14,9 @@ import (
 	"github.com/warthog618/gpiod/device/rpi"
 )
 
+// standardFanUnitTachTimeout is the time without tachometer edges after which the fan is considered to stand still
+const standardFanUnitTachTimeout = 2 * time.Second
+
 type standardFanUnitBcm2711 struct {
 	GpioChip0           *gpiod.Chip
 	SetFanSpeedPwmFunc  func(speed uint8) error
@@ -20,17 +25,20 @@ type standardFanUnitBcm2711 struct {
 	// Fan tachometer input
 	fanEdgeLine      *gpiod.Line
 	lastFanEdgeEvent *gpiod.LineEvent
-	fanRpm           float64
+	// fanRpmMu guards fanRpm and lastFanEdge, which are written by the edge event handler
+	fanRpmMu    sync.Mutex
+	fanRpm      float64
+	lastFanEdge time.Time
 }
 
-func (fu standardFanUnitBcm2711) Kind() FanUnitKind {
+func (fu *standardFanUnitBcm2711) Kind() FanUnitKind {
 	if fu.DisableRpmReporting {
 		return FanUnitKindStandardNoRPM
 	}
 	return FanUnitKindStandard
 }
 
-func (fu standardFanUnitBcm2711) Run(ctx context.Context) error {
+func (fu *standardFanUnitBcm2711) Run(ctx context.Context) error {
 	var err error
 	fanUnit.WithLabelValues("standard").Set(1)
 
@@ -76,7 +84,10 @@ func (fu *standardFanUnitBcm2711) handleFanEdge(evt gpiod.LineEvent) {
 	rpm := (ticksPerSecond * 60.0) / 2.0 // 2 ticks per revolution
 
 	// Simple moving average to smooth out the fan speed
+	fu.fanRpmMu.Lock()
+	defer fu.fanRpmMu.Unlock()
 	fu.fanRpm = (rpm * 0.1) + (fu.fanRpm * 0.9)
+	fu.lastFanEdge = time.Now()
 	fanSpeed.Set(fu.fanRpm)
 }
 
@@ -88,7 +99,17 @@ func (fu *standardFanUnitBcm2711) SetLed(_ context.Context, _ led.Color) error {
 	return nil
 }
 
+// FanSpeedRPM returns the current fan speed in rotations per minute.
+// A fan without tachometer edges for standardFanUnitTachTimeout stands still, instead of reporting the last average.
 func (fu *standardFanUnitBcm2711) FanSpeedRPM(_ context.Context) (float64, error) {
+	fu.fanRpmMu.Lock()
+	defer fu.fanRpmMu.Unlock()
+
+	if time.Since(fu.lastFanEdge) > standardFanUnitTachTimeout {
+		fu.fanRpm = 0
+		fanSpeed.Set(0)
+	}
+
 	return fu.fanRpm, nil
 }
 
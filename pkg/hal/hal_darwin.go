@@ -0,0 +1,6 @@
+//go:build darwin
+
+package hal
+
+// fallbackDriver is used if the driver can't be detected. There is no blade hardware on macOS.
+const fallbackDriver = DriverSimulated
@@ -35,6 +35,16 @@ func (m *ComputeBladeHalMock) GetFanRPM() (float64, error) {
 	return args.Get(0).(float64), args.Error(1)
 }
 
+func (m *ComputeBladeHalMock) GetFanUnitKind() FanUnitKind {
+	args := m.Called()
+	return args.Get(0).(FanUnitKind)
+}
+
+func (m *ComputeBladeHalMock) SetFanSpinUpKick(enabled bool) error {
+	args := m.Called(enabled)
+	return args.Error(0)
+}
+
 func (m *ComputeBladeHalMock) SetStealthMode(enabled bool) error {
 	args := m.Called(enabled)
 	return args.Error(0)
@@ -64,3 +74,13 @@ func (m *ComputeBladeHalMock) GetTemperature() (float64, error) {
 	args := m.Called()
 	return args.Get(0).(float64), args.Error(1)
 }
+
+func (m *ComputeBladeHalMock) GetAirFlowTemperature() (float64, error) {
+	args := m.Called()
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *ComputeBladeHalMock) ComputeModule() string {
+	args := m.Called()
+	return args.String(0)
+}
@@ -0,0 +1,301 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/spechtlabs/go-otel-utils/otelzap"
+	"go.uber.org/zap"
+)
+
+// fails if SimulatedHal does not implement ComputeBladeHal
+var _ ComputeBladeHal = &SimulatedHal{}
+
+func init() {
+	RegisterDriver(Driver{
+		Name: DriverSimulated,
+		New: func(ctx context.Context, opts ComputeBladeHalOpts) (ComputeBladeHal, error) {
+			return NewSimulatedHal(ctx, opts, nil)
+		},
+	})
+}
+
+// SimulatedHal simulates a compute blade as scripted by a Scenario, so the agent can run without blade hardware
+type SimulatedHal struct {
+	logger   *zap.Logger
+	clock    util.Clock
+	scenario Scenario
+	fanKind  FanUnitKind
+	start    time.Time
+
+	mu            sync.Mutex
+	isStealthMode bool
+	leds          [2]led.Color
+	// fanPercent is the commanded fan speed, and fanRPM the simulated fan speed at fanUpdated
+	fanPercent uint8
+	fanRPM     float64
+	fanUpdated time.Time
+	// lastButtonPress is the time of the button press last returned by WaitForEdgeButtonPress
+	lastButtonPress time.Time
+}
+
+// NewSimulatedHal creates a SimulatedHal playing the scenario configured in opts. Without a scenario, the blade idles
+// at 42°C. If clock is nil, the real clock is used.
+func NewSimulatedHal(_ context.Context, opts ComputeBladeHalOpts, clock util.Clock) (*SimulatedHal, error) {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	scenario := &Scenario{}
+	if opts.SimulatedScenario != "" {
+		var err error
+		if scenario, err = LoadScenario(opts.SimulatedScenario); err != nil {
+			return nil, err
+		}
+	}
+	fanKind, err := scenario.fanUnitKind()
+	if err != nil {
+		return nil, err
+	}
+	if scenario.Fan.MaxRPM == 0 {
+		scenario.Fan.MaxRPM = defaultScenarioFanMaxRPM
+	}
+	slices.Sort(scenario.ButtonPresses)
+	slices.SortFunc(scenario.Power, func(a, b ScenarioPowerChange) int {
+		return cmp.Compare(a.At, b.At)
+	})
+
+	logger := otelzap.L().Named("hal").Named("simulated-cm4")
+	logger.Warn("Using simulated hal", zap.String("scenario", opts.SimulatedScenario))
+
+	computeModule.WithLabelValues("simulated").Set(1)
+	fanUnit.WithLabelValues("simulated").Set(1)
+
+	start := clock.Now()
+	return &SimulatedHal{
+		logger:          logger,
+		clock:           clock,
+		scenario:        *scenario,
+		fanKind:         fanKind,
+		start:           start,
+		fanUpdated:      start,
+		lastButtonPress: start.Add(-time.Nanosecond),
+	}, nil
+}
+
+// elapsed returns the time of the scenario at t
+func (m *SimulatedHal) elapsed(t time.Time) time.Duration {
+	elapsed := t.Sub(m.start)
+	if m.scenario.Loop > 0 {
+		elapsed %= m.scenario.Loop
+	}
+	return elapsed
+}
+
+func (m *SimulatedHal) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (m *SimulatedHal) Close() error {
+	return nil
+}
+
+// updateFan advances the simulated fan speed to now, approaching the commanded fan speed exponentially
+func (m *SimulatedHal) updateFan(now time.Time) {
+	target := m.scenario.Fan.MaxRPM * float64(m.fanPercent) / 100
+	if m.fanPercent == 0 || m.fanPercent < m.scenario.Fan.StallPercent || within(m.scenario.Fan.Failures, m.elapsed(now)) {
+		target = 0
+	}
+
+	if timeConstant := m.scenario.Fan.TimeConstant; timeConstant > 0 {
+		m.fanRPM = target + (m.fanRPM-target)*math.Exp(-now.Sub(m.fanUpdated).Seconds()/timeConstant.Seconds())
+	} else {
+		m.fanRPM = target
+	}
+	m.fanUpdated = now
+}
+
+func (m *SimulatedHal) SetFanSpeed(percent uint8) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logger.Info("SetFanSpeed", zap.Uint8("percent", percent))
+	m.updateFan(m.clock.Now())
+	m.fanPercent = percent
+	fanTargetPercent.Set(float64(percent))
+	return nil
+}
+
+// SetFanSpinUpKick is a noop, the simulated fan is never kicked
+func (m *SimulatedHal) SetFanSpinUpKick(_ bool) error {
+	return nil
+}
+
+func (m *SimulatedHal) GetFanRPM() (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Like on the blade, a standard fan unit without fan speed reporting never sees the fan spinning
+	if m.fanKind == FanUnitKindStandardNoRPM {
+		return 0, nil
+	}
+
+	m.updateFan(m.clock.Now())
+	fanSpeed.Set(m.fanRPM)
+	return m.fanRPM, nil
+}
+
+func (m *SimulatedHal) GetFanUnitKind() FanUnitKind {
+	return m.fanKind
+}
+
+func (m *SimulatedHal) SetStealthMode(enabled bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if enabled {
+		stealthModeEnabled.Set(1)
+	} else {
+		stealthModeEnabled.Set(0)
+	}
+
+	m.isStealthMode = enabled
+	m.logger.Info("SetStealthMode", zap.Bool("enabled", enabled))
+	return nil
+}
+
+func (m *SimulatedHal) StealthModeActive() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.isStealthMode
+}
+
+func (m *SimulatedHal) GetPowerStatus() (PowerStatus, error) {
+	status := PowerStatus(PowerPoe802at)
+	elapsed := m.elapsed(m.clock.Now())
+	for _, change := range m.scenario.Power {
+		if change.At > elapsed {
+			break
+		}
+		// The scenario has been validated
+		status, _ = parsePowerStatus(change.Status)
+	}
+
+	m.logger.Info("GetPowerStatus", zap.String("status", status.String()))
+	for _, s := range []PowerStatus{PowerPoe802at, PowerPoeOrUsbC} {
+		if s == status {
+			powerStatus.WithLabelValues(fmt.Sprint(s)).Set(1)
+		} else {
+			powerStatus.WithLabelValues(fmt.Sprint(s)).Set(0)
+		}
+	}
+	return status, nil
+}
+
+// nextButtonPress returns the time of the first scripted button press after t, false if there is none
+func (m *SimulatedHal) nextButtonPress(after time.Time) (time.Time, bool) {
+	if len(m.scenario.ButtonPresses) == 0 {
+		return time.Time{}, false
+	}
+
+	cycleStart := m.start
+	if m.scenario.Loop > 0 && after.After(m.start) {
+		cycleStart = m.start.Add(after.Sub(m.start) / m.scenario.Loop * m.scenario.Loop)
+	}
+	for {
+		for _, press := range m.scenario.ButtonPresses {
+			if t := cycleStart.Add(press); t.After(after) {
+				return t, true
+			}
+		}
+		if m.scenario.Loop == 0 {
+			return time.Time{}, false
+		}
+		cycleStart = cycleStart.Add(m.scenario.Loop)
+	}
+}
+
+// WaitForEdgeButtonPress blocks until the next button press scripted by the scenario
+func (m *SimulatedHal) WaitForEdgeButtonPress(ctx context.Context) error {
+	m.mu.Lock()
+	press, ok := m.nextButtonPress(m.lastButtonPress)
+	m.mu.Unlock()
+	if !ok {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-m.clock.After(press.Sub(m.clock.Now())):
+	}
+
+	m.mu.Lock()
+	m.lastButtonPress = press
+	m.mu.Unlock()
+
+	m.logger.Info("Edge button pressed")
+	edgeButtonEventCount.Inc()
+	return nil
+}
+
+func (m *SimulatedHal) SetLed(idx LedIndex, color led.Color) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if int(idx) >= len(m.leds) {
+		return errors.New("invalid LED index")
+	}
+	m.leds[idx] = color
+
+	ledColorChangeEventCount.Inc()
+	m.logger.Debug("SetLed", zap.Uint("idx", uint(idx)), zap.Any("color", color))
+	return nil
+}
+
+// Led returns the color last set on the LED
+func (m *SimulatedHal) Led(idx LedIndex) led.Color {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.leds[idx]
+}
+
+func (m *SimulatedHal) GetTemperature() (float64, error) {
+	elapsed := m.elapsed(m.clock.Now())
+	if within(m.scenario.TemperatureFailures, elapsed) {
+		return -1, errors.New("simulated temperature sensor failure")
+	}
+
+	temp := interpolate(m.scenario.Temperature, elapsed, defaultScenarioTemperature)
+	socTemperature.Set(temp)
+	return temp, nil
+}
+
+func (m *SimulatedHal) GetAirFlowTemperature() (float64, error) {
+	// Like on the blade, only the smart fan unit has an air flow sensor
+	if m.fanKind != FanUnitKindSmart {
+		return -1 * math.MaxFloat32, nil
+	}
+
+	temp := interpolate(m.scenario.AirFlowTemperature, m.elapsed(m.clock.Now()), defaultScenarioAirFlowTemperature)
+	airFlowTemperature.Set(temp)
+	return temp, nil
+}
+
+func (m *SimulatedHal) ComputeModule() string {
+	return "simulated"
+}
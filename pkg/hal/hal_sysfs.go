@@ -0,0 +1,350 @@
+//go:build linux && !tinygo
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/warthog618/gpiod"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DriverSysfs drives the blade through kernel interfaces only, e.g. on a CM5
+	DriverSysfs = "sysfs"
+
+	sysfsDefaultPwmChip         = "/sys/class/pwm/pwmchip0"
+	sysfsDefaultPwmFrequency    = 25000 // Noctua fans expect a 25kHz signal
+	sysfsDefaultTemperature     = "/sys/class/thermal/thermal_zone0/temp"
+	sysfsDefaultGpioChip        = "gpiochip0"
+	sysfsDefaultEdgeButtonLine  = 20
+	sysfsDefaultStealthModeLine = 21
+	sysfsDefaultPoeLine         = 23
+
+	sysfsDebounceInterval = 100 * time.Millisecond
+)
+
+func init() {
+	RegisterDriver(Driver{
+		Name: DriverSysfs,
+		Detect: func(dt DeviceTree) bool {
+			return dt.IsCompatible("brcm,bcm2712")
+		},
+		New: NewSysfsHal,
+	})
+}
+
+// sysfsHal controls the blade through the sysfs PWM, hwmon and thermal interfaces and gpiod, without accessing
+// registers of the SoC. The LEDs are driven by a configurable LedBackend.
+type sysfsHal struct {
+	opts  SysfsHalOpts
+	model string
+
+	gpioChip        *gpiod.Chip
+	edgeButtonLine  *gpiod.Line
+	stealthModeLine *gpiod.Line
+	poeLine         *gpiod.Line
+
+	// edgeButtonMu guards the edge button state, which is written by the edge event handler
+	edgeButtonMu        sync.Mutex
+	edgeButtonWatchChan chan struct{}
+	lastEdgeButtonPress time.Time
+
+	fanUnit FanUnit
+	leds    LedBackend
+}
+
+// withDefaults returns the options with defaults applied to unset options
+func (opts SysfsHalOpts) withDefaults() SysfsHalOpts {
+	if opts.PwmChip == "" {
+		opts.PwmChip = sysfsDefaultPwmChip
+	}
+	if opts.PwmFrequency == 0 {
+		opts.PwmFrequency = sysfsDefaultPwmFrequency
+	}
+	if opts.Temperature == "" {
+		opts.Temperature = sysfsDefaultTemperature
+	}
+	if opts.GpioChip == "" {
+		opts.GpioChip = sysfsDefaultGpioChip
+	}
+	// GPIO line 0 is a valid line, only lines that aren't configured at all fall back to the defaults
+	opts.EdgeButtonLine = lineOrDefault(opts.EdgeButtonLine, sysfsDefaultEdgeButtonLine)
+	opts.StealthModeLine = lineOrDefault(opts.StealthModeLine, sysfsDefaultStealthModeLine)
+	opts.PoeLine = lineOrDefault(opts.PoeLine, sysfsDefaultPoeLine)
+	return opts
+}
+
+// lineOrDefault returns line, or a pointer to defaultLine if line is nil
+func lineOrDefault(line *int, defaultLine int) *int {
+	if line == nil {
+		return &defaultLine
+	}
+	return line
+}
+
+// NewSysfsHal creates a HAL controlling the blade through kernel interfaces only
+func NewSysfsHal(ctx context.Context, opts ComputeBladeHalOpts) (ComputeBladeHal, error) {
+	sysfsOpts := opts.Sysfs.withDefaults()
+
+	leds, herr := NewLedBackend(sysfsOpts.Leds)
+	if herr != nil {
+		return nil, herr
+	}
+
+	gpioChip, err := gpiod.NewChip(sysfsOpts.GpioChip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GPIO chip %s: %w", sysfsOpts.GpioChip, err)
+	}
+
+	s := &sysfsHal{
+		opts:                sysfsOpts,
+		gpioChip:            gpioChip,
+		edgeButtonWatchChan: make(chan struct{}),
+		leds:                leds,
+	}
+
+	// The device tree only describes the compute module, the HAL works without it
+	dt, err := ReadDeviceTree(opts.deviceTreeRoot())
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to read device tree", zap.Error(err))
+	}
+	s.model = dt.Model
+	moduleName := dt.ComputeModule()
+	if moduleName == "" {
+		moduleName = "unknown"
+	}
+	if s.model == "" {
+		s.model = moduleName
+	}
+	computeModule.WithLabelValues(moduleName).Set(1)
+
+	log.FromContext(ctx).Info("starting hal setup", zap.String("hal", DriverSysfs))
+	if err := s.setup(ctx, opts); err != nil {
+		return nil, errors.Join(err, s.Close())
+	}
+	return s, nil
+}
+
+func (s *sysfsHal) setup(ctx context.Context, opts ComputeBladeHalOpts) error {
+	var err error
+
+	s.edgeButtonLine, err = s.gpioChip.RequestLine(*s.opts.EdgeButtonLine,
+		gpiod.WithEventHandler(s.handleEdgeButtonEdge),
+		gpiod.WithFallingEdge, gpiod.WithPullUp, gpiod.WithDebounce(50*time.Millisecond))
+	if err != nil {
+		return fmt.Errorf("failed to request edge button line %d: %w", *s.opts.EdgeButtonLine, err)
+	}
+
+	s.poeLine, err = s.gpioChip.RequestLine(*s.opts.PoeLine, gpiod.AsInput, gpiod.WithPullUp)
+	if err != nil {
+		return fmt.Errorf("failed to request PoE detection line %d: %w", *s.opts.PoeLine, err)
+	}
+
+	s.stealthModeLine, err = s.gpioChip.RequestLine(*s.opts.StealthModeLine, gpiod.AsOutput(1))
+	if err != nil {
+		return fmt.Errorf("failed to request stealth mode line %d: %w", *s.opts.StealthModeLine, err)
+	}
+
+	// Setup correct fan unit
+	smartFanUnitPresent := false
+	if s.opts.SmartFanUnitDevice != "" {
+		log.FromContext(ctx).Info("detecting fan unit")
+		detectCtx, cancel := context.WithTimeout(ctx, 3*time.Second) // temp events are sent every 2 seconds
+		defer cancel()
+
+		smartFanUnitPresent, err = SmartFanUnitPresent(detectCtx, s.opts.SmartFanUnitDevice)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Info("no smart fan unit detected, assuming standard fan unit")
+		}
+	}
+
+	if smartFanUnitPresent {
+		log.FromContext(ctx).Info("detected smart fan unit")
+		if s.fanUnit, err = NewSmartFanUnit(s.opts.SmartFanUnitDevice); err != nil {
+			return err
+		}
+	} else {
+		pwm, err := OpenSysfsPwm(s.opts.PwmChip, s.opts.PwmChannel, s.opts.PwmFrequency)
+		if err != nil {
+			return fmt.Errorf("failed to open fan PWM: %w", err)
+		}
+
+		tachometer := s.opts.FanTachometer
+		if !opts.RpmReportingStandardFanUnit {
+			tachometer = ""
+		}
+		s.fanUnit = NewSysfsFanUnit(pwm, tachometer)
+	}
+
+	if opts.FanSpinUpKick > 0 {
+		s.fanUnit = NewSpinUpKickFanUnit(s.fanUnit, opts.FanSpinUpKick, nil)
+	}
+
+	return nil
+}
+
+// Close releases the GPIO lines and the fan unit
+func (s *sysfsHal) Close() error {
+	var errs []error
+	if s.fanUnit != nil {
+		errs = append(errs, s.fanUnit.Close())
+	}
+	for _, line := range []*gpiod.Line{s.edgeButtonLine, s.poeLine, s.stealthModeLine} {
+		if line != nil {
+			errs = append(errs, line.Close())
+		}
+	}
+	errs = append(errs, s.gpioChip.Close())
+	return errors.Join(errs...)
+}
+
+func (s *sysfsHal) Run(parentCtx context.Context) error {
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+
+	group := errgroup.Group{}
+
+	group.Go(func() error {
+		defer cancel()
+		return s.fanUnit.Run(ctx)
+	})
+
+	return group.Wait()
+}
+
+// handleEdgeButtonEdge wakes up all waiting for the edge button, ignoring bounces
+func (s *sysfsHal) handleEdgeButtonEdge(evt gpiod.LineEvent) {
+	s.edgeButtonMu.Lock()
+	defer s.edgeButtonMu.Unlock()
+
+	// Despite debouncing, we still get multiple events for a single button press
+	now := time.Now()
+	if now.Sub(s.lastEdgeButtonPress) < sysfsDebounceInterval {
+		return
+	}
+	s.lastEdgeButtonPress = now
+
+	edgeButtonEventCount.Inc()
+	close(s.edgeButtonWatchChan)
+	s.edgeButtonWatchChan = make(chan struct{})
+}
+
+// WaitForEdgeButtonPress blocks until the edge button or the button of the fan unit has been pressed
+func (s *sysfsHal) WaitForEdgeButtonPress(parentCtx context.Context) error {
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+
+	s.edgeButtonMu.Lock()
+	edgeButtonWatchChan := s.edgeButtonWatchChan
+	s.edgeButtonMu.Unlock()
+
+	fanUnitChan := make(chan struct{})
+	go func() {
+		err := s.fanUnit.WaitForButtonPress(ctx)
+		if err != nil && err != context.Canceled {
+			log.FromContext(ctx).WithError(err).Error("failed to wait for button press")
+		} else {
+			close(fanUnitChan)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-edgeButtonWatchChan:
+		return nil
+	case <-fanUnitChan:
+		return nil
+	}
+}
+
+func (s *sysfsHal) SetFanSpeed(percent uint8) error {
+	fanTargetPercent.Set(float64(percent))
+	return s.fanUnit.SetFanSpeedPercent(context.TODO(), percent)
+}
+
+func (s *sysfsHal) SetFanSpinUpKick(enabled bool) error {
+	return setFanUnitSpinUpKick(s.fanUnit, enabled)
+}
+
+func (s *sysfsHal) GetFanRPM() (float64, error) {
+	return s.fanUnit.FanSpeedRPM(context.TODO())
+}
+
+func (s *sysfsHal) GetFanUnitKind() FanUnitKind {
+	return s.fanUnit.Kind()
+}
+
+func (s *sysfsHal) GetPowerStatus() (PowerStatus, error) {
+	val, err := s.poeLine.Value()
+	if err != nil {
+		return PowerPoeOrUsbC, err
+	}
+
+	if val > 0 {
+		powerStatus.WithLabelValues(fmt.Sprint(PowerPoe802at)).Set(1)
+		powerStatus.WithLabelValues(fmt.Sprint(PowerPoeOrUsbC)).Set(0)
+		return PowerPoe802at, nil
+	}
+	powerStatus.WithLabelValues(fmt.Sprint(PowerPoe802at)).Set(0)
+	powerStatus.WithLabelValues(fmt.Sprint(PowerPoeOrUsbC)).Set(1)
+	return PowerPoeOrUsbC, nil
+}
+
+func (s *sysfsHal) SetStealthMode(enable bool) error {
+	if enable {
+		stealthModeEnabled.Set(1)
+		return s.stealthModeLine.SetValue(1)
+	}
+	stealthModeEnabled.Set(0)
+	return s.stealthModeLine.SetValue(0)
+}
+
+func (s *sysfsHal) StealthModeActive() bool {
+	val, err := s.stealthModeLine.Value()
+	if err != nil {
+		return false
+	}
+	return val > 0
+}
+
+func (s *sysfsHal) SetLed(idx LedIndex, color led.Color) error {
+	// Update the fan unit LED if the index is the same as the fan unit LED index
+	if idx == LedEdge {
+		if err := s.fanUnit.SetLed(context.TODO(), color); err != nil {
+			return err
+		}
+	}
+
+	ledColorChangeEventCount.Inc()
+	return s.leds.SetLed(idx, color)
+}
+
+// GetTemperature returns the current temperature of the SoC
+func (s *sysfsHal) GetTemperature() (float64, error) {
+	temp, err := ReadSysfsTemperature(s.opts.Temperature)
+	if err != nil {
+		return -1, err
+	}
+
+	socTemperature.Set(temp)
+	return temp, nil
+}
+
+// GetAirFlowTemperature returns the current temperature of the air flow as reported by the fan unit
+func (s *sysfsHal) GetAirFlowTemperature() (float64, error) {
+	temp, err := s.fanUnit.AirFlowTemperature(context.TODO())
+	return float64(temp), err
+}
+
+func (s *sysfsHal) ComputeModule() string {
+	return s.model
+}
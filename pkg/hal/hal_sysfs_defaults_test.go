@@ -0,0 +1,29 @@
+//go:build linux && !tinygo
+
+package hal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSysfsHalOpts_WithDefaults(t *testing.T) {
+	t.Parallel()
+
+	opts := SysfsHalOpts{}.withDefaults()
+	assert.Equal(t, sysfsDefaultPwmChip, opts.PwmChip)
+	assert.Equal(t, uint(sysfsDefaultPwmFrequency), opts.PwmFrequency)
+	assert.Equal(t, sysfsDefaultTemperature, opts.Temperature)
+	assert.Equal(t, sysfsDefaultGpioChip, opts.GpioChip)
+	assert.Equal(t, sysfsDefaultEdgeButtonLine, *opts.EdgeButtonLine)
+	assert.Equal(t, sysfsDefaultStealthModeLine, *opts.StealthModeLine)
+	assert.Equal(t, sysfsDefaultPoeLine, *opts.PoeLine)
+
+	// GPIO line 0 is a valid line
+	line0 := 0
+	opts = SysfsHalOpts{EdgeButtonLine: &line0, StealthModeLine: &line0, PoeLine: &line0}.withDefaults()
+	assert.Equal(t, 0, *opts.EdgeButtonLine)
+	assert.Equal(t, 0, *opts.StealthModeLine)
+	assert.Equal(t, 0, *opts.PoeLine)
+}
@@ -0,0 +1,27 @@
+//go:build linux && !tinygo
+
+package hal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSysfsHal_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := hal.NewSysfsHal(context.Background(), hal.ComputeBladeHalOpts{
+		Sysfs: hal.SysfsHalOpts{Leds: hal.LedBackendOpts{Backend: "ws2812"}},
+	})
+	assert.EqualError(t, err, `unknown LED backend "ws2812"`)
+
+	gpioChip := filepath.Join(t.TempDir(), "gpiochip0")
+	_, err = hal.NewSysfsHal(context.Background(), hal.ComputeBladeHalOpts{
+		Sysfs: hal.SysfsHalOpts{GpioChip: gpioChip},
+	})
+	assert.ErrorContains(t, err, "failed to open GPIO chip "+gpioChip)
+}
@@ -0,0 +1,121 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+const (
+	defaultLedBrightness = 100
+	defaultLedGamma      = 1.0
+	maxLedGamma          = 5.0
+)
+
+// LedCorrection decorates a ComputeBladeHal, scaling all LED colors by a global brightness and applying gamma
+// correction before they are written to the LEDs
+type LedCorrection struct {
+	ComputeBladeHal
+
+	mu    sync.Mutex
+	gamma float64
+	// brightness is the brightness in percent
+	brightness uint8
+	// table maps color components to the corrected values
+	table [256]uint8
+	// colors are the uncorrected colors last set per LED
+	colors map[LedIndex]led.Color
+}
+
+// NewLedCorrection wraps blade to correct all LED colors according to the led_brightness and led_gamma options
+func NewLedCorrection(blade ComputeBladeHal, opts ComputeBladeHalOpts) (*LedCorrection, humane.Error) {
+	gamma := opts.LedGamma
+	if gamma == 0 {
+		gamma = defaultLedGamma
+	}
+	if gamma < 0 || gamma > maxLedGamma {
+		return nil, humane.New(fmt.Sprintf("LED gamma %.2f out of range", gamma),
+			fmt.Sprintf("Ensure hal.led_gamma is between 0 and %.0f, e.g. 2.2", maxLedGamma),
+		)
+	}
+
+	brightness := opts.LedBrightness
+	if brightness == 0 {
+		brightness = defaultLedBrightness
+	}
+	if brightness > 100 {
+		return nil, humane.New(fmt.Sprintf("LED brightness %d%% out of range", brightness),
+			"Ensure hal.led_brightness is between 1 and 100, use stealth mode to turn off the LEDs",
+		)
+	}
+
+	c := &LedCorrection{
+		ComputeBladeHal: blade,
+		gamma:           gamma,
+		colors:          make(map[LedIndex]led.Color),
+	}
+	c.setBrightness(brightness)
+	return c, nil
+}
+
+// SetLed sets the corrected color of the LED
+func (c *LedCorrection) SetLed(idx LedIndex, color led.Color) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.colors[idx] = color
+	return c.ComputeBladeHal.SetLed(idx, c.correct(color))
+}
+
+// SetBrightness changes the brightness in percent and updates the LEDs right away
+func (c *LedCorrection) SetBrightness(percent uint8) error {
+	if percent > 100 {
+		return humane.New(fmt.Sprintf("LED brightness %d%% out of range", percent),
+			"Use a brightness between 0 and 100",
+		)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setBrightness(percent)
+	for idx, color := range c.colors {
+		if err := c.ComputeBladeHal.SetLed(idx, c.correct(color)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Brightness returns the brightness in percent
+func (c *LedCorrection) Brightness() uint8 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.brightness
+}
+
+// setBrightness updates the correction table. The caller must hold the lock.
+func (c *LedCorrection) setBrightness(percent uint8) {
+	c.brightness = percent
+	ledBrightness.Set(float64(percent))
+
+	scale := float64(percent) / 100
+	for value := range c.table {
+		c.table[value] = uint8(math.Round(255 * math.Pow(float64(value)/255*scale, c.gamma)))
+	}
+}
+
+// correct returns the color to write to the LED. The caller must hold the lock.
+func (c *LedCorrection) correct(color led.Color) led.Color {
+	return led.Color{
+		Red:   c.table[color.Red],
+		Green: c.table[color.Green],
+		Blue:  c.table[color.Blue],
+	}
+}
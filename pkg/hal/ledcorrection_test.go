@@ -0,0 +1,81 @@
+//go:build !tinygo
+
+package hal_test
+
+import (
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedCorrection_Defaults(t *testing.T) {
+	t.Parallel()
+
+	blade := &hal.ComputeBladeHalMock{}
+	blade.On("SetLed", hal.LedEdge, led.Color{Green: 16}).Once().Return(nil)
+
+	correction, err := hal.NewLedCorrection(blade, hal.ComputeBladeHalOpts{})
+	if err != nil {
+		t.Fatalf("Failed to create LED correction: %v", err)
+	}
+
+	// Colors are passed on unchanged
+	assert.NoError(t, correction.SetLed(hal.LedEdge, led.Color{Green: 16}))
+	assert.Equal(t, uint8(100), correction.Brightness())
+	blade.AssertExpectations(t)
+}
+
+func TestLedCorrection_BrightnessAndGamma(t *testing.T) {
+	t.Parallel()
+
+	blade := &hal.ComputeBladeHalMock{}
+	blade.On("SetLed", hal.LedEdge, led.Color{Red: 56, Green: 1, Blue: 255}).Once().Return(nil)
+	blade.On("SetLed", hal.LedTop, led.Color{Red: 255}).Once().Return(nil)
+	// Changing the brightness updates the LEDs right away
+	blade.On("SetLed", hal.LedEdge, led.Color{Red: 12, Green: 0, Blue: 55}).Once().Return(nil)
+	blade.On("SetLed", hal.LedTop, led.Color{Red: 55}).Once().Return(nil)
+
+	correction, err := hal.NewLedCorrection(blade, hal.ComputeBladeHalOpts{LedGamma: 2.2})
+	if err != nil {
+		t.Fatalf("Failed to create LED correction: %v", err)
+	}
+
+	assert.NoError(t, correction.SetLed(hal.LedEdge, led.Color{Red: 128, Green: 16, Blue: 255}))
+	assert.NoError(t, correction.SetLed(hal.LedTop, led.Color{Red: 255}))
+
+	assert.NoError(t, correction.SetBrightness(50))
+	assert.Equal(t, uint8(50), correction.Brightness())
+	blade.AssertExpectations(t)
+}
+
+func TestLedCorrection_Brightness(t *testing.T) {
+	t.Parallel()
+
+	blade := &hal.ComputeBladeHalMock{}
+	blade.On("SetLed", hal.LedTop, led.Color{Red: 128, Green: 8, Blue: 0}).Once().Return(nil)
+	blade.On("SetLed", hal.LedTop, led.Color{}).Once().Return(nil)
+
+	correction, err := hal.NewLedCorrection(blade, hal.ComputeBladeHalOpts{LedBrightness: 50})
+	if err != nil {
+		t.Fatalf("Failed to create LED correction: %v", err)
+	}
+
+	assert.NoError(t, correction.SetLed(hal.LedTop, led.Color{Red: 255, Green: 16}))
+	assert.NoError(t, correction.SetBrightness(0))
+
+	assert.EqualError(t, correction.SetBrightness(101), "LED brightness 101% out of range")
+	assert.Equal(t, uint8(0), correction.Brightness())
+	blade.AssertExpectations(t)
+}
+
+func TestLedCorrection_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := hal.NewLedCorrection(&hal.ComputeBladeHalMock{}, hal.ComputeBladeHalOpts{LedGamma: -1})
+	assert.EqualError(t, err, "LED gamma -1.00 out of range")
+
+	_, err = hal.NewLedCorrection(&hal.ComputeBladeHalMock{}, hal.ComputeBladeHalOpts{LedBrightness: 150})
+	assert.EqualError(t, err, "LED brightness 150% out of range")
+}
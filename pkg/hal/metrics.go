@@ -53,6 +53,11 @@ var (
 		Name:      "fan_unit",
 		Help:      "Fan unit",
 	}, []string{"type"})
+	ledBrightness = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "computeblade",
+		Name:      "led_brightness_percent",
+		Help:      "LED brightness in percent",
+	})
 	edgeButtonEventCount = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: "computeblade",
 		Name:      "edge_button_event_count",
@@ -0,0 +1,216 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/sierrasoftworks/humane-errors-go"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultScenarioTemperature        = 42
+	defaultScenarioAirFlowTemperature = 30
+	defaultScenarioFanMaxRPM          = 2500
+)
+
+// Scenario scripts the hardware simulated by the SimulatedHal. All times are offsets from the start of the HAL.
+// Values are linearly interpolated between points and held before the first and after the last point.
+type Scenario struct {
+	// FanUnit is the kind of the simulated fan unit: standard (default), standard_no_rpm or smart
+	FanUnit string `yaml:"fan_unit"`
+	// Temperature is the SoC temperature in °C, 42°C if empty
+	Temperature []ScenarioPoint `yaml:"temperature"`
+	// TemperatureFailures are the intervals the SoC temperature can't be read
+	TemperatureFailures []ScenarioInterval `yaml:"temperature_failures"`
+	// AirFlowTemperature is the air flow temperature in °C reported by a smart fan unit, 30°C if empty
+	AirFlowTemperature []ScenarioPoint `yaml:"airflow_temperature"`
+	// Fan describes how the fan speed follows the commanded fan speed
+	Fan ScenarioFan `yaml:"fan"`
+	// Power are the changes of the power status, the blade is powered by PoE+ until the first change
+	Power []ScenarioPowerChange `yaml:"power"`
+	// ButtonPresses are the times the edge button is pressed
+	ButtonPresses []time.Duration `yaml:"button_presses"`
+	// Loop restarts the scenario after the given duration, 0 plays it once
+	Loop time.Duration `yaml:"loop"`
+}
+
+// ScenarioPoint is a value at a point in time of the scenario
+type ScenarioPoint struct {
+	At    time.Duration `yaml:"at"`
+	Value float64       `yaml:"value"`
+}
+
+// ScenarioInterval is a time interval of the scenario. An interval without end lasts until the end of the scenario.
+type ScenarioInterval struct {
+	From time.Duration `yaml:"from"`
+	To   time.Duration `yaml:"to"`
+}
+
+// ScenarioFan describes the simulated fan
+type ScenarioFan struct {
+	// MaxRPM is the fan speed at 100%, defaults to 2500 RPM
+	MaxRPM float64 `yaml:"max_rpm"`
+	// TimeConstant is the time the fan takes to cover ~63% of a speed change, 0 changes the speed instantly
+	TimeConstant time.Duration `yaml:"time_constant"`
+	// StallPercent is the commanded fan speed below which the fan stands still
+	StallPercent uint8 `yaml:"stall_percent"`
+	// Failures are the intervals the fan stands still regardless of the commanded fan speed
+	Failures []ScenarioInterval `yaml:"failures"`
+}
+
+// ScenarioPowerChange changes the power status (poe+ or poeOrUsbC) at a point in time of the scenario
+type ScenarioPowerChange struct {
+	At     time.Duration `yaml:"at"`
+	Status string        `yaml:"status"`
+}
+
+// LoadScenario reads and validates a scenario from a YAML file
+func LoadScenario(path string) (*Scenario, humane.Error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, humane.Wrap(err, "failed to read simulation scenario",
+			fmt.Sprintf("ensure %s exists and is readable", path),
+		)
+	}
+
+	var scenario Scenario
+	if err := yaml.Unmarshal(data, &scenario); err != nil {
+		return nil, humane.Wrap(err, "failed to parse simulation scenario",
+			"ensure the scenario is valid YAML and durations are given like 30s or 5m",
+		)
+	}
+
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return &scenario, nil
+}
+
+// Validate checks the scenario for invalid values
+func (s *Scenario) Validate() humane.Error {
+	if _, err := s.fanUnitKind(); err != nil {
+		return err
+	}
+	if s.Loop < 0 {
+		return humane.New("scenario loop must not be negative", "use 0 to play the scenario once")
+	}
+	if s.Fan.MaxRPM < 0 {
+		return humane.New("scenario fan max_rpm must not be negative", "omit max_rpm to use 2500 RPM")
+	}
+
+	for _, points := range [][]ScenarioPoint{s.Temperature, s.AirFlowTemperature} {
+		for _, point := range points {
+			if err := s.validateTime(point.At); err != nil {
+				return err
+			}
+		}
+	}
+	for _, change := range s.Power {
+		if err := s.validateTime(change.At); err != nil {
+			return err
+		}
+		if _, err := parsePowerStatus(change.Status); err != nil {
+			return err
+		}
+	}
+	for _, press := range s.ButtonPresses {
+		if err := s.validateTime(press); err != nil {
+			return err
+		}
+	}
+	for _, intervals := range [][]ScenarioInterval{s.TemperatureFailures, s.Fan.Failures} {
+		for _, interval := range intervals {
+			if err := s.validateTime(interval.From); err != nil {
+				return err
+			}
+			if interval.To != 0 && interval.To < interval.From {
+				return humane.New(fmt.Sprintf("scenario interval from %s to %s ends before it starts", interval.From, interval.To),
+					"omit to for an interval lasting until the end of the scenario",
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateTime checks that a point in time lies within the scenario
+func (s *Scenario) validateTime(at time.Duration) humane.Error {
+	if at < 0 {
+		return humane.New(fmt.Sprintf("scenario time %s must not be negative", at),
+			"times are offsets from the start of the agent",
+		)
+	}
+	if s.Loop > 0 && at >= s.Loop {
+		return humane.New(fmt.Sprintf("scenario time %s is beyond the loop of %s", at, s.Loop),
+			"increase the loop duration or remove the point",
+		)
+	}
+	return nil
+}
+
+// fanUnitKind returns the kind of the simulated fan unit
+func (s *Scenario) fanUnitKind() (FanUnitKind, humane.Error) {
+	switch s.FanUnit {
+	case "", "standard":
+		return FanUnitKindStandard, nil
+	case "standard_no_rpm":
+		return FanUnitKindStandardNoRPM, nil
+	case "smart":
+		return FanUnitKindSmart, nil
+	default:
+		return FanUnitKindStandard, humane.New(fmt.Sprintf("unknown scenario fan unit %q", s.FanUnit),
+			"use one of standard, standard_no_rpm or smart",
+		)
+	}
+}
+
+// parsePowerStatus parses a power status as returned by PowerStatus.String
+func parsePowerStatus(status string) (PowerStatus, humane.Error) {
+	for _, powerStatus := range []PowerStatus{PowerPoe802at, PowerPoeOrUsbC} {
+		if status == powerStatus.String() {
+			return powerStatus, nil
+		}
+	}
+	return PowerPoe802at, humane.New(fmt.Sprintf("unknown scenario power status %q", status),
+		"use one of poe+ or poeOrUsbC",
+	)
+}
+
+// interpolate returns the value of the points at the given time of the scenario, or fallback if there are no points
+func interpolate(points []ScenarioPoint, at time.Duration, fallback float64) float64 {
+	if len(points) == 0 {
+		return fallback
+	}
+
+	sorted := slices.SortedFunc(slices.Values(points), func(a, b ScenarioPoint) int {
+		return cmp.Compare(a.At, b.At)
+	})
+	if at <= sorted[0].At {
+		return sorted[0].Value
+	}
+	for idx := 1; idx < len(sorted); idx++ {
+		prev, next := sorted[idx-1], sorted[idx]
+		if at < next.At {
+			t := float64(at-prev.At) / float64(next.At-prev.At)
+			return prev.Value + t*(next.Value-prev.Value)
+		}
+	}
+	return sorted[len(sorted)-1].Value
+}
+
+// within returns whether the time of the scenario lies within any of the intervals
+func within(intervals []ScenarioInterval, at time.Duration) bool {
+	for _, interval := range intervals {
+		if at >= interval.From && (interval.To == 0 || at < interval.To) {
+			return true
+		}
+	}
+	return false
+}
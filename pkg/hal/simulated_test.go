@@ -0,0 +1,273 @@
+//go:build !tinygo
+
+package hal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/stretchr/testify/assert"
+)
+
+// manualClock only advances when advanced by the test or when waited on
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newScenarioHal(t *testing.T, scenario string) (*hal.SimulatedHal, *manualClock) {
+	t.Helper()
+
+	clk := &manualClock{now: time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)}
+	simulated, err := hal.NewSimulatedHal(context.Background(), hal.ComputeBladeHalOpts{SimulatedScenario: scenario}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create simulated HAL: %v", err)
+	}
+	return simulated, clk
+}
+
+func TestSimulatedHal_Defaults(t *testing.T) {
+	t.Parallel()
+
+	simulated, _ := newScenarioHal(t, "")
+
+	temp, err := simulated.GetTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 42.0, temp)
+
+	assert.EqualValues(t, hal.FanUnitKindStandard, simulated.GetFanUnitKind())
+	assert.NoError(t, simulated.SetFanSpeed(40))
+	rpm, err := simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, rpm)
+
+	status, err := simulated.GetPowerStatus()
+	assert.NoError(t, err)
+	assert.EqualValues(t, hal.PowerPoe802at, status)
+
+	assert.NoError(t, simulated.SetLed(hal.LedTop, led.Color{Red: 255}))
+	assert.Equal(t, led.Color{Red: 255}, simulated.Led(hal.LedTop))
+	assert.Error(t, simulated.SetLed(hal.LedIndex(2), led.Color{}))
+
+	// Without scripted button presses, waiting blocks until cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, simulated.WaitForEdgeButtonPress(ctx), context.Canceled)
+}
+
+func TestSimulatedHal_Temperature(t *testing.T) {
+	t.Parallel()
+
+	simulated, clk := newScenarioHal(t, "testdata/scenario.yaml")
+
+	temp, err := simulated.GetTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, temp)
+	airflow, err := simulated.GetAirFlowTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, airflow)
+
+	clk.advance(5 * time.Minute)
+	temp, err = simulated.GetTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, temp)
+	airflow, err = simulated.GetAirFlowTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, airflow)
+
+	// The sensor fails from 12m to 12m30s
+	clk.advance(7*time.Minute + 10*time.Second)
+	_, err = simulated.GetTemperature()
+	assert.Error(t, err)
+
+	clk.advance(time.Minute)
+	temp, err = simulated.GetTemperature()
+	assert.NoError(t, err)
+	assert.InDelta(t, 48.33, temp, 0.01)
+
+	// The last value is held until the scenario loops after 15m
+	clk.advance(time.Minute + 50*time.Second)
+	temp, err = simulated.GetTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, temp)
+
+	clk.advance(5 * time.Minute)
+	temp, err = simulated.GetTemperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, temp)
+}
+
+func TestSimulatedHal_Fan(t *testing.T) {
+	t.Parallel()
+
+	simulated, clk := newScenarioHal(t, "testdata/scenario.yaml")
+	assert.EqualValues(t, hal.FanUnitKindSmart, simulated.GetFanUnitKind())
+
+	// The fan approaches the commanded speed with a time constant of 2s
+	assert.NoError(t, simulated.SetFanSpeed(50))
+	clk.advance(2 * time.Second)
+	rpm, err := simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.InDelta(t, 2500*0.632, rpm, 1)
+
+	clk.advance(time.Minute)
+	rpm, err = simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.InDelta(t, 2500, rpm, 1)
+
+	// Below the stall speed, the fan stands still
+	assert.NoError(t, simulated.SetFanSpeed(10))
+	clk.advance(time.Minute)
+	rpm, err = simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, rpm, 1)
+
+	// The fan fails from 11m to 11m30s
+	assert.NoError(t, simulated.SetFanSpeed(100))
+	clk.advance(9*time.Minute + 10*time.Second)
+	rpm, err = simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, rpm, 1)
+
+	clk.advance(time.Minute)
+	rpm, err = simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.InDelta(t, 5000, rpm, 1)
+}
+
+func TestSimulatedHal_FanUnitWithoutRPM(t *testing.T) {
+	t.Parallel()
+
+	scenario := filepath.Join(t.TempDir(), "scenario.yaml")
+	assert.NoError(t, os.WriteFile(scenario, []byte("fan_unit: standard_no_rpm\n"), 0o644))
+	simulated, _ := newScenarioHal(t, scenario)
+
+	assert.EqualValues(t, hal.FanUnitKindStandardNoRPM, simulated.GetFanUnitKind())
+	assert.NoError(t, simulated.SetFanSpeed(100))
+	rpm, err := simulated.GetFanRPM()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, rpm)
+
+	// Only the smart fan unit has an air flow sensor
+	airflow, err := simulated.GetAirFlowTemperature()
+	assert.NoError(t, err)
+	assert.Less(t, airflow, -1000.0)
+}
+
+func TestSimulatedHal_PowerStatus(t *testing.T) {
+	t.Parallel()
+
+	simulated, clk := newScenarioHal(t, "testdata/scenario.yaml")
+
+	expected := []struct {
+		at     time.Duration
+		status hal.PowerStatus
+	}{
+		{at: 0, status: hal.PowerPoe802at},
+		{at: 5 * time.Minute, status: hal.PowerPoeOrUsbC},
+		{at: 9 * time.Minute, status: hal.PowerPoeOrUsbC},
+		{at: 10 * time.Minute, status: hal.PowerPoe802at},
+		{at: 19 * time.Minute, status: hal.PowerPoe802at},
+		{at: 20 * time.Minute, status: hal.PowerPoeOrUsbC},
+	}
+
+	start := clk.Now()
+	for _, e := range expected {
+		clk.now = start.Add(e.at)
+		status, err := simulated.GetPowerStatus()
+		assert.NoError(t, err)
+		assert.Equal(t, e.status, status, "at %s", e.at)
+	}
+}
+
+func TestSimulatedHal_ButtonPresses(t *testing.T) {
+	t.Parallel()
+
+	simulated, clk := newScenarioHal(t, "testdata/scenario.yaml")
+	start := clk.Now()
+
+	// Presses at 1m and 3m, repeated every 15m
+	for _, expected := range []time.Duration{
+		time.Minute, 3 * time.Minute, 16 * time.Minute, 18 * time.Minute, 31 * time.Minute,
+	} {
+		assert.NoError(t, simulated.WaitForEdgeButtonPress(context.Background()))
+		assert.Equal(t, expected, clk.Now().Sub(start))
+	}
+}
+
+func TestLoadScenario_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		scenario string
+		errMsg   string
+	}{
+		{
+			name:     "Invalid YAML",
+			scenario: "temperature: 42",
+			errMsg:   "failed to parse simulation scenario",
+		},
+		{
+			name:     "Unknown fan unit",
+			scenario: "fan_unit: turbo",
+			errMsg:   `unknown scenario fan unit "turbo"`,
+		},
+		{
+			name:     "Unknown power status",
+			scenario: "power: [{at: 1m, status: battery}]",
+			errMsg:   `unknown scenario power status "battery"`,
+		},
+		{
+			name:     "Time beyond loop",
+			scenario: "loop: 5m\nbutton_presses: [5m]",
+			errMsg:   "scenario time 5m0s is beyond the loop of 5m0s",
+		},
+		{
+			name:     "Negative time",
+			scenario: "temperature: [{at: -1m, value: 42}]",
+			errMsg:   "scenario time -1m0s must not be negative",
+		},
+		{
+			name:     "Interval ending before start",
+			scenario: "fan: {failures: [{from: 2m, to: 1m}]}",
+			errMsg:   "scenario interval from 2m0s to 1m0s ends before it starts",
+		},
+	}
+
+	for _, tc := range testCases {
+		scenario := tc.scenario
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "scenario.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(scenario), 0o644))
+			_, err := hal.LoadScenario(path)
+			assert.ErrorContains(t, err, expectedErrMsg)
+		})
+	}
+
+	_, err := hal.LoadScenario(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read simulation scenario")
+}
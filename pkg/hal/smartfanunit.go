@@ -5,8 +5,10 @@ package hal
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/compute-blade-community/compute-blade-agent/pkg/events"
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
@@ -77,6 +79,10 @@ func NewSmartFanUnit(portName string) (FanUnit, error) {
 
 //var ErrCommunicationFailed = errors.New("communication failed") // FIXME: still required or dead code?
 
+// smartFanUnitSpeedTimeout is the time without fan speed updates after which the reported fan speed is considered stale.
+// The fan unit reports its fan speed every 2 seconds.
+const smartFanUnitSpeedTimeout = 10 * time.Second
+
 const (
 	inboundTopic = "smartfanunit:inbound"
 	//outboundTopic = "smartfanunit:outbound" // FIXME: still required or dead code?
@@ -86,8 +92,11 @@ type smartFanUnit struct {
 	rwc io.ReadWriteCloser
 	mu  sync.Mutex // write mutex
 
-	speed   smartfanunit.FanSpeedRPMPacket
-	airflow smartfanunit.AirFlowTemperaturePacket
+	// speedMu guards speed and speedUpdated
+	speedMu      sync.Mutex
+	speed        smartfanunit.FanSpeedRPMPacket
+	speedUpdated time.Time
+	airflow      smartfanunit.AirFlowTemperaturePacket
 
 	eb events.EventBus
 }
@@ -133,10 +142,16 @@ func (fuc *smartFanUnit) Run(parentCtx context.Context) error {
 				return nil
 			case pktAny := <-sub.C():
 				rawPkt := pktAny.(proto.Packet)
-				if err := fuc.speed.FromPacket(rawPkt); err != nil && !errors.Is(err, proto.ErrChecksumMismatch) {
-					return err
+				fuc.speedMu.Lock()
+				err := fuc.speed.FromPacket(rawPkt)
+				if err == nil {
+					fuc.speedUpdated = time.Now()
 				}
 				fanSpeed.Set(float64(fuc.speed.RPM))
+				fuc.speedMu.Unlock()
+				if err != nil && !errors.Is(err, proto.ErrChecksumMismatch) {
+					return err
+				}
 			}
 		}
 	})
@@ -179,7 +194,15 @@ func (fuc *smartFanUnit) SetLed(ctx context.Context, color led.Color) error {
 }
 
 // FanSpeedRPM returns the current fan speed in rotations per minute.
+// An error is returned if the fan unit didn't report the fan speed recently.
 func (fuc *smartFanUnit) FanSpeedRPM(_ context.Context) (float64, error) {
+	fuc.speedMu.Lock()
+	defer fuc.speedMu.Unlock()
+
+	if time.Since(fuc.speedUpdated) > smartFanUnitSpeedTimeout {
+		return float64(fuc.speed.RPM), fmt.Errorf("no fan speed reported by the smart fan unit since %s", fuc.speedUpdated.Format(time.RFC3339))
+	}
+
 	return float64(fuc.speed.RPM), nil
 }
 
@@ -0,0 +1,127 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/log"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+)
+
+// spinUpKickPercent is the fan speed a standing fan is kicked with
+const spinUpKickPercent = 100
+
+// SpinUpKickFanUnit is a FanUnit kicking a standing fan at full duty before the requested fan speed is applied
+type SpinUpKickFanUnit interface {
+	FanUnit
+
+	// SetSpinUpKick enables or disables the kick, e.g. to measure the duty cycle a standing fan starts at.
+	// Disabling the kick ends a running kick, applying the requested fan speed.
+	SetSpinUpKick(ctx context.Context, enabled bool) error
+}
+
+// spinUpKickFanUnit decorates a FanUnit, restarting a standing fan with a short full-duty kick before the requested
+// fan speed is applied. Many fans don't start reliably at low duty cycles.
+type spinUpKickFanUnit struct {
+	FanUnit
+	duration time.Duration
+	clock    util.Clock
+
+	mu sync.Mutex
+	// disabled indicates that the kick is disabled, see SetSpinUpKick
+	disabled bool
+	// percent is the fan speed last requested
+	percent uint8
+	// kicking indicates that a kick is running, the requested fan speed is applied once it ends
+	kicking bool
+	// kick identifies the current kick, so an aborted kick doesn't end a later one
+	kick uint64
+}
+
+// NewSpinUpKickFanUnit wraps fanUnit to kick the fan at full duty for duration whenever it starts from a standstill.
+// If clock is nil, the real clock is used.
+func NewSpinUpKickFanUnit(fanUnit FanUnit, duration time.Duration, clock util.Clock) SpinUpKickFanUnit {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &spinUpKickFanUnit{
+		FanUnit:  fanUnit,
+		duration: duration,
+		clock:    clock,
+	}
+}
+
+// SetFanSpeedPercent sets the fan speed in percent, kicking the fan first if it is standing.
+func (fu *spinUpKickFanUnit) SetFanSpeedPercent(ctx context.Context, percent uint8) error {
+	fu.mu.Lock()
+	defer fu.mu.Unlock()
+
+	previous := fu.percent
+	fu.percent = percent
+
+	if fu.kicking {
+		// Stopping the fan aborts the kick, any other speed is applied once the kick ends
+		if percent == 0 {
+			fu.kicking = false
+			return fu.FanUnit.SetFanSpeedPercent(ctx, percent)
+		}
+		return nil
+	}
+
+	if fu.disabled || previous != 0 || percent == 0 || percent >= spinUpKickPercent {
+		return fu.FanUnit.SetFanSpeedPercent(ctx, percent)
+	}
+
+	if err := fu.FanUnit.SetFanSpeedPercent(ctx, spinUpKickPercent); err != nil {
+		return err
+	}
+
+	fu.kicking = true
+	fu.kick++
+	go fu.endKick(context.WithoutCancel(ctx), fu.kick, fu.clock.After(fu.duration))
+
+	return nil
+}
+
+// SetSpinUpKick enables or disables the kick. Disabling the kick ends a running kick, applying the requested fan speed.
+func (fu *spinUpKickFanUnit) SetSpinUpKick(ctx context.Context, enabled bool) error {
+	fu.mu.Lock()
+	defer fu.mu.Unlock()
+
+	fu.disabled = !enabled
+	if enabled || !fu.kicking {
+		return nil
+	}
+
+	fu.kicking = false
+	return fu.FanUnit.SetFanSpeedPercent(ctx, fu.percent)
+}
+
+// setFanUnitSpinUpKick enables or disables the spin-up kick of fanUnit. Noop if fanUnit isn't kicked.
+func setFanUnitSpinUpKick(fanUnit FanUnit, enabled bool) error {
+	if kicked, ok := fanUnit.(SpinUpKickFanUnit); ok {
+		return kicked.SetSpinUpKick(context.TODO(), enabled)
+	}
+	return nil
+}
+
+// endKick applies the requested fan speed once the kick is over
+func (fu *spinUpKickFanUnit) endKick(ctx context.Context, kick uint64, done <-chan time.Time) {
+	<-done
+
+	fu.mu.Lock()
+	defer fu.mu.Unlock()
+
+	if !fu.kicking || fu.kick != kick {
+		return
+	}
+	fu.kicking = false
+
+	if err := fu.FanUnit.SetFanSpeedPercent(ctx, fu.percent); err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to set fan speed after spin-up kick")
+	}
+}
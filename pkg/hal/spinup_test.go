@@ -0,0 +1,234 @@
+//go:build !tinygo
+
+package hal_test
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/fancontroller"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFanUnit records the fan speeds set on it
+type recordingFanUnit struct {
+	hal.FanUnit
+
+	mu     sync.Mutex
+	speeds []uint8
+}
+
+func (fu *recordingFanUnit) SetFanSpeedPercent(_ context.Context, percent uint8) error {
+	fu.mu.Lock()
+	defer fu.mu.Unlock()
+
+	fu.speeds = append(fu.speeds, percent)
+	return nil
+}
+
+func (fu *recordingFanUnit) Speeds() []uint8 {
+	fu.mu.Lock()
+	defer fu.mu.Unlock()
+
+	return slices.Clone(fu.speeds)
+}
+
+func TestSpinUpKickFanUnit(t *testing.T) {
+	t.Parallel()
+
+	kickDone := make(chan time.Time)
+	clk := &util.MockClock{}
+	clk.On("After", 2*time.Second).Return(kickDone)
+
+	recorder := &recordingFanUnit{}
+	fanUnit := hal.NewSpinUpKickFanUnit(recorder, 2*time.Second, clk)
+	ctx := context.Background()
+
+	// Starting a standing fan kicks it at full duty
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 30))
+	assert.Equal(t, []uint8{100}, recorder.Speeds())
+
+	// Speed changes during the kick are applied once it ends
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 40))
+	assert.Equal(t, []uint8{100}, recorder.Speeds())
+
+	kickDone <- time.Now()
+	assert.Eventually(t, func() bool {
+		return slices.Equal([]uint8{100, 40}, recorder.Speeds())
+	}, time.Second, time.Millisecond)
+
+	// A running fan is not kicked
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 20))
+	assert.Equal(t, []uint8{100, 40, 20}, recorder.Speeds())
+
+	// Stopping the fan is applied immediately
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 0))
+	assert.Equal(t, []uint8{100, 40, 20, 0}, recorder.Speeds())
+	clk.AssertExpectations(t)
+}
+
+func TestSpinUpKickFanUnit_StopDuringKick(t *testing.T) {
+	t.Parallel()
+
+	firstKickDone := make(chan time.Time)
+	secondKickDone := make(chan time.Time)
+	clk := &util.MockClock{}
+	clk.On("After", time.Second).Once().Return(firstKickDone)
+	clk.On("After", time.Second).Once().Return(secondKickDone)
+
+	recorder := &recordingFanUnit{}
+	fanUnit := hal.NewSpinUpKickFanUnit(recorder, time.Second, clk)
+	ctx := context.Background()
+
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 30))
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 0))
+	assert.Equal(t, []uint8{100, 0}, recorder.Speeds())
+
+	// The aborted kick doesn't end the next one
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 25))
+	firstKickDone <- time.Now()
+	assert.Never(t, func() bool {
+		return len(recorder.Speeds()) > 3
+	}, 50*time.Millisecond, time.Millisecond)
+
+	secondKickDone <- time.Now()
+	assert.Eventually(t, func() bool {
+		return slices.Equal([]uint8{100, 0, 100, 25}, recorder.Speeds())
+	}, time.Second, time.Millisecond)
+
+	// Speeds at or above the kick don't need one
+	recorder2 := &recordingFanUnit{}
+	fanUnit = hal.NewSpinUpKickFanUnit(recorder2, time.Second, clk)
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 100))
+	assert.Equal(t, []uint8{100}, recorder2.Speeds())
+	clk.AssertExpectations(t)
+}
+
+// inertiaFanUnit is a fan with inertia. Its speed approaches the target speed exponentially, a standing fan only
+// starts at 30% and a spinning fan stalls below 15%.
+type inertiaFanUnit struct {
+	hal.FanUnit
+
+	percent uint8
+	rpm     float64
+}
+
+func (fu *inertiaFanUnit) SetFanSpeedPercent(_ context.Context, percent uint8) error {
+	fu.percent = percent
+	return nil
+}
+
+func (fu *inertiaFanUnit) FanSpeedRPM(_ context.Context) (float64, error) {
+	return fu.rpm, nil
+}
+
+func (fu *inertiaFanUnit) advance(d time.Duration) {
+	target := 0.0
+	if fu.percent >= 30 || (fu.rpm >= 100 && fu.percent >= 15) {
+		target = 5000 * float64(fu.percent) / 100
+	}
+	fu.rpm = target + (fu.rpm-target)*math.Exp(-d.Seconds()/2)
+}
+
+// calibrationFanUnit calibrates a FanUnit, advancing the inertia fan whenever the calibration waits
+type calibrationFanUnit struct {
+	fanUnit hal.FanUnit
+	fan     *inertiaFanUnit
+	now     time.Time
+}
+
+func (c *calibrationFanUnit) SetFanSpeed(percent uint8) error {
+	return c.fanUnit.SetFanSpeedPercent(context.Background(), percent)
+}
+
+func (c *calibrationFanUnit) GetFanRPM() (float64, error) {
+	return c.fanUnit.FanSpeedRPM(context.Background())
+}
+
+func (c *calibrationFanUnit) Now() time.Time {
+	return c.now
+}
+
+func (c *calibrationFanUnit) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	c.fan.advance(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestSpinUpKickFanUnit_Calibration(t *testing.T) {
+	t.Parallel()
+
+	config := fancontroller.CalibrationConfig{
+		Steps:          []uint8{0, 10, 20, 30, 40, 60, 80, 100},
+		SampleInterval: time.Second,
+		SettleTimeout:  30 * time.Second,
+		StallRPM:       200,
+	}
+
+	// The kick never ends on its own, so every step below 100% is measured at full duty while it is enabled
+	kickDone := make(chan time.Time)
+	kickClk := &util.MockClock{}
+	kickClk.On("After", 2*time.Second).Return(kickDone)
+
+	fan := &inertiaFanUnit{}
+	fanUnit := hal.NewSpinUpKickFanUnit(fan, 2*time.Second, kickClk)
+	calibrationFan := &calibrationFanUnit{fanUnit: fanUnit, fan: fan, now: time.Date(2025, time.June, 6, 12, 0, 0, 0, time.UTC)}
+
+	calibration, err := fancontroller.Calibrate(context.Background(), calibrationFan, config, calibrationFan)
+	require.Nil(t, err)
+	assert.Equal(t, uint8(10), calibration.SpinUpPercent, "the kick hides the duty cycle the fan starts at")
+
+	// Disabling the kick ends the running kick and measures the fan itself
+	require.NoError(t, fanUnit.SetSpinUpKick(context.Background(), false))
+	calibration, err = fancontroller.Calibrate(context.Background(), calibrationFan, config, calibrationFan)
+	require.Nil(t, err)
+	assert.Equal(t, uint8(30), calibration.SpinUpPercent)
+	assert.Equal(t, uint8(20), calibration.MinRunningPercent)
+	kickClk.AssertNumberOfCalls(t, "After", 1)
+
+	// Once enabled again, a standing fan is kicked
+	require.NoError(t, fanUnit.SetSpinUpKick(context.Background(), true))
+	require.NoError(t, fanUnit.SetFanSpeedPercent(context.Background(), 0))
+	require.NoError(t, fanUnit.SetFanSpeedPercent(context.Background(), 40))
+	assert.Equal(t, uint8(100), fan.percent)
+	kickClk.AssertNumberOfCalls(t, "After", 2)
+}
+
+func TestSpinUpKickFanUnit_DisableDuringKick(t *testing.T) {
+	t.Parallel()
+
+	kickDone := make(chan time.Time)
+	clk := &util.MockClock{}
+	clk.On("After", time.Second).Return(kickDone)
+
+	recorder := &recordingFanUnit{}
+	fanUnit := hal.NewSpinUpKickFanUnit(recorder, time.Second, clk)
+	ctx := context.Background()
+
+	// Disabling the kick applies the requested fan speed right away
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 30))
+	assert.NoError(t, fanUnit.SetSpinUpKick(ctx, false))
+	assert.Equal(t, []uint8{100, 30}, recorder.Speeds())
+
+	// The ended kick doesn't apply the fan speed again
+	kickDone <- time.Now()
+	assert.Never(t, func() bool {
+		return len(recorder.Speeds()) > 2
+	}, 50*time.Millisecond, time.Millisecond)
+
+	// Without the kick, a standing fan is started with the requested fan speed
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 0))
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(ctx, 20))
+	assert.Equal(t, []uint8{100, 30, 0, 20}, recorder.Speeds())
+	clk.AssertExpectations(t)
+}
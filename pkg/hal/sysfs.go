@@ -0,0 +1,65 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ReadSysfsTemperature reads a temperature in millidegrees Celsius, as exposed by the thermal and hwmon sysfs
+// interfaces, and returns it in °C. The path may contain glob patterns (e.g. /sys/class/nvme/nvme0/hwmon*/temp1_input),
+// in which case the first match is used.
+func ReadSysfsTemperature(path string) (float64, error) {
+	milliCelsius, err := readSysfsInt(path)
+	if err != nil {
+		return -1, err
+	}
+
+	return float64(milliCelsius) / 1000.0, nil
+}
+
+// ReadSysfsFanRPM reads a fan speed in rotations per minute, as exposed by the hwmon sysfs interface (fan*_input).
+// Like ReadSysfsTemperature, the path may contain glob patterns.
+func ReadSysfsFanRPM(path string) (float64, error) {
+	rpm, err := readSysfsInt(path)
+	if err != nil {
+		return -1, err
+	}
+
+	return float64(rpm), nil
+}
+
+// readSysfsInt reads an integer attribute from the first file matching path
+func readSysfsInt(path string) (int, error) {
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return -1, err
+	}
+	if len(matches) == 0 {
+		return -1, fmt.Errorf("no sysfs attribute found at %s: %w", path, os.ErrNotExist)
+	}
+
+	raw, err := os.ReadFile(matches[0])
+	if err != nil {
+		return -1, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(raw)))
+}
+
+// writeSysfs writes a value to a sysfs attribute. Unlike os.WriteFile, it never creates the attribute.
+func writeSysfs(path string, value string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(value); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
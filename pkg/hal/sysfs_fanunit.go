@@ -0,0 +1,75 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"context"
+	"math"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+)
+
+// sysfsFanUnit is a standard fan unit driven by a sysfs PWM channel, with the fan speed optionally read from hwmon
+type sysfsFanUnit struct {
+	pwm *SysfsPwm
+	// tachometer is the hwmon fan*_input reporting the fan speed, empty if the fan speed isn't reported
+	tachometer string
+}
+
+// NewSysfsFanUnit creates a standard fan unit driven by the PWM channel. If tachometer is empty, the fan speed isn't
+// reported.
+func NewSysfsFanUnit(pwm *SysfsPwm, tachometer string) FanUnit {
+	return &sysfsFanUnit{
+		pwm:        pwm,
+		tachometer: tachometer,
+	}
+}
+
+func (fu *sysfsFanUnit) Kind() FanUnitKind {
+	if fu.tachometer == "" {
+		return FanUnitKindStandardNoRPM
+	}
+	return FanUnitKindStandard
+}
+
+func (fu *sysfsFanUnit) Run(ctx context.Context) error {
+	fanUnit.WithLabelValues("standard").Set(1)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (fu *sysfsFanUnit) SetFanSpeedPercent(_ context.Context, percent uint8) error {
+	return fu.pwm.SetDutyCycle(percent)
+}
+
+func (fu *sysfsFanUnit) SetLed(_ context.Context, _ led.Color) error {
+	return nil
+}
+
+// FanSpeedRPM returns the fan speed as reported by hwmon, or 0 if the fan speed isn't reported
+func (fu *sysfsFanUnit) FanSpeedRPM(_ context.Context) (float64, error) {
+	if fu.tachometer == "" {
+		return 0, nil
+	}
+
+	rpm, err := ReadSysfsFanRPM(fu.tachometer)
+	if err != nil {
+		return 0, err
+	}
+	fanSpeed.Set(rpm)
+	return rpm, nil
+}
+
+func (fu *sysfsFanUnit) WaitForButtonPress(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (fu *sysfsFanUnit) AirFlowTemperature(_ context.Context) (float32, error) {
+	return -1 * math.MaxFloat32, nil
+}
+
+func (fu *sysfsFanUnit) Close() error {
+	return nil
+}
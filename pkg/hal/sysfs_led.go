@@ -0,0 +1,133 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+const (
+	// LedBackendNone discards all LED colors, for boards without LEDs the agent can control
+	LedBackendNone = "none"
+	// LedBackendSysfs shows LED colors on multicolor LEDs of the sysfs LED class (/sys/class/leds)
+	LedBackendSysfs = "sysfs"
+)
+
+// LedBackend shows the colors of the top and edge LED, for HALs that don't drive the LEDs themselves
+type LedBackend interface {
+	SetLed(idx LedIndex, color led.Color) error
+}
+
+// NewLedBackend creates the LED backend selected by opts.Backend
+func NewLedBackend(opts LedBackendOpts) (LedBackend, humane.Error) {
+	switch opts.Backend {
+	case "", LedBackendNone:
+		return noLedBackend{}, nil
+	case LedBackendSysfs:
+		backend := &sysfsLedBackend{}
+		names := [2]string{"top", "edge"}
+		for idx, path := range [2]string{opts.Top, opts.Edge} {
+			// LEDs without a path are discarded
+			if path == "" {
+				continue
+			}
+			sysfsLed, err := OpenSysfsLed(path)
+			if err != nil {
+				return nil, humane.Wrap(err, fmt.Sprintf("failed to open the %s LED", names[idx]),
+					fmt.Sprintf("ensure %s is a multicolor LED of the sysfs LED class", path),
+				)
+			}
+			backend.leds[idx] = sysfsLed
+		}
+		return backend, nil
+	default:
+		return nil, humane.New(fmt.Sprintf("unknown LED backend %q", opts.Backend),
+			fmt.Sprintf("use one of %s or %s", LedBackendNone, LedBackendSysfs),
+		)
+	}
+}
+
+// noLedBackend discards all LED colors
+type noLedBackend struct{}
+
+func (noLedBackend) SetLed(idx LedIndex, _ led.Color) error {
+	if idx > LedEdge {
+		return fmt.Errorf("invalid led index %d, supported: [0, 1]", idx)
+	}
+	return nil
+}
+
+// sysfsLedBackend shows LED colors on sysfs multicolor LEDs
+type sysfsLedBackend struct {
+	leds [2]*SysfsLed
+}
+
+func (b *sysfsLedBackend) SetLed(idx LedIndex, color led.Color) error {
+	if idx > LedEdge {
+		return fmt.Errorf("invalid led index %d, supported: [0, 1]", idx)
+	}
+	if b.leds[idx] == nil {
+		return nil
+	}
+	return b.leds[idx].SetColor(color)
+}
+
+// SysfsLed is a multicolor LED of the sysfs LED class, e.g. /sys/class/leds/rgb:status
+type SysfsLed struct {
+	path          string
+	maxBrightness int
+	// channels are the colors of the LED in the order of multi_intensity, as listed by multi_index
+	channels []string
+}
+
+// OpenSysfsLed reads the channels and maximum brightness of the multicolor LED at path
+func OpenSysfsLed(path string) (*SysfsLed, error) {
+	maxBrightness, err := readSysfsInt(filepath.Join(path, "max_brightness"))
+	if err != nil {
+		return nil, err
+	}
+	multiIndex, err := os.ReadFile(filepath.Join(path, "multi_index"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SysfsLed{
+		path:          path,
+		maxBrightness: maxBrightness,
+		channels:      strings.Fields(string(multiIndex)),
+	}, nil
+}
+
+// SetColor sets the color of the LED. Channels other than red, green and blue are turned off.
+func (l *SysfsLed) SetColor(color led.Color) error {
+	intensities := make([]string, len(l.channels))
+	for idx, channel := range l.channels {
+		var value uint8
+		switch channel {
+		case "red":
+			value = color.Red
+		case "green":
+			value = color.Green
+		case "blue":
+			value = color.Blue
+		}
+		intensities[idx] = strconv.Itoa(int(value) * l.maxBrightness / 255)
+	}
+	if err := writeSysfs(filepath.Join(l.path, "multi_intensity"), strings.Join(intensities, " ")); err != nil {
+		return err
+	}
+
+	// The intensities are relative to the brightness of the LED
+	brightness := l.maxBrightness
+	if color == (led.Color{}) {
+		brightness = 0
+	}
+	return writeSysfs(filepath.Join(l.path, "brightness"), strconv.Itoa(brightness))
+}
@@ -0,0 +1,102 @@
+//go:build !tinygo
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// sysfsPwmExportTimeout is the time udev has to create the attributes of an exported PWM channel
+	sysfsPwmExportTimeout = time.Second
+	sysfsPwmExportPoll    = 10 * time.Millisecond
+)
+
+// SysfsPwm drives a PWM channel through the sysfs PWM interface (/sys/class/pwm)
+type SysfsPwm struct {
+	// path is the directory of the exported channel, e.g. /sys/class/pwm/pwmchip0/pwm1
+	path   string
+	period time.Duration
+
+	mu      sync.Mutex
+	percent uint8
+}
+
+// OpenSysfsPwm exports a channel of the PWM chip, unless it is exported already, and enables it with the given
+// frequency and a duty cycle of 0%
+func OpenSysfsPwm(chip string, channel uint, frequency uint) (*SysfsPwm, error) {
+	if frequency == 0 {
+		return nil, errors.New("PWM frequency must not be 0")
+	}
+
+	path := filepath.Join(chip, fmt.Sprintf("pwm%d", channel))
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := writeSysfs(filepath.Join(chip, "export"), strconv.FormatUint(uint64(channel), 10)); err != nil {
+			return nil, fmt.Errorf("failed to export PWM channel %d of %s: %w", channel, chip, err)
+		}
+		if err := waitForSysfs(filepath.Join(path, "enable")); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	pwm := &SysfsPwm{
+		path:   path,
+		period: time.Second / time.Duration(frequency),
+	}
+
+	// The duty cycle must never exceed the period, so it's cleared before the period is changed
+	if err := writeSysfs(filepath.Join(path, "duty_cycle"), "0"); err != nil {
+		return nil, err
+	}
+	if err := writeSysfs(filepath.Join(path, "period"), strconv.FormatInt(pwm.period.Nanoseconds(), 10)); err != nil {
+		return nil, err
+	}
+	if err := writeSysfs(filepath.Join(path, "enable"), "1"); err != nil {
+		return nil, err
+	}
+
+	return pwm, nil
+}
+
+// waitForSysfs waits until the sysfs attribute exists
+func waitForSysfs(path string) error {
+	deadline := time.Now().Add(sysfsPwmExportTimeout)
+	for {
+		_, err := os.Stat(path)
+		if err == nil || !errors.Is(err, os.ErrNotExist) || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(sysfsPwmExportPoll)
+	}
+}
+
+// SetDutyCycle sets the duty cycle in percent
+func (p *SysfsPwm) SetDutyCycle(percent uint8) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	percent = min(percent, 100)
+	dutyCycle := p.period.Nanoseconds() * int64(percent) / 100
+	if err := writeSysfs(filepath.Join(p.path, "duty_cycle"), strconv.FormatInt(dutyCycle, 10)); err != nil {
+		return err
+	}
+
+	p.percent = percent
+	return nil
+}
+
+// DutyCycle returns the duty cycle last set in percent
+func (p *SysfsPwm) DutyCycle() uint8 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.percent
+}
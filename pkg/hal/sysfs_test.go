@@ -0,0 +1,188 @@
+//go:build !tinygo
+
+package hal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeSysfsFiles creates a fake sysfs tree below root, mapping paths to contents
+func writeSysfsFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		path = filepath.Join(root, path)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// readSysfsFile returns the content of a file of the fake sysfs tree
+func readSysfsFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestReadSysfsTemperature(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	hwmonDir := filepath.Join(root, "nvme0", "hwmon3")
+	assert.NoError(t, os.MkdirAll(hwmonDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(hwmonDir, "temp1_input"), []byte("42850\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "invalid"), []byte("hot\n"), 0o644))
+
+	// Direct path
+	temp, err := hal.ReadSysfsTemperature(filepath.Join(hwmonDir, "temp1_input"))
+	assert.NoError(t, err)
+	assert.Equal(t, 42.85, temp)
+
+	// Glob pattern, as hwmon indices are not stable
+	temp, err = hal.ReadSysfsTemperature(filepath.Join(root, "nvme0", "hwmon*", "temp1_input"))
+	assert.NoError(t, err)
+	assert.Equal(t, 42.85, temp)
+
+	// Missing sensor
+	_, err = hal.ReadSysfsTemperature(filepath.Join(root, "nvme1", "hwmon*", "temp1_input"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// Invalid content
+	_, err = hal.ReadSysfsTemperature(filepath.Join(root, "invalid"))
+	assert.Error(t, err)
+}
+
+func TestReadSysfsFanRPM(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"cooling_fan/hwmon2/fan1_input": "2480\n",
+	})
+
+	rpm, err := hal.ReadSysfsFanRPM(filepath.Join(root, "cooling_fan", "hwmon*", "fan1_input"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2480.0, rpm)
+
+	_, err = hal.ReadSysfsFanRPM(filepath.Join(root, "missing", "fan1_input"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSysfsPwm(t *testing.T) {
+	t.Parallel()
+
+	chip := t.TempDir()
+	writeSysfsFiles(t, chip, map[string]string{
+		"export":          "",
+		"pwm1/period":     "0\n",
+		"pwm1/duty_cycle": "0\n",
+		"pwm1/enable":     "0\n",
+	})
+
+	pwm, err := hal.OpenSysfsPwm(chip, 1, 25000)
+	assert.NoError(t, err)
+	// The exported channel is reused
+	assert.Equal(t, "", readSysfsFile(t, filepath.Join(chip, "export")))
+	assert.Equal(t, "40000", readSysfsFile(t, filepath.Join(chip, "pwm1", "period")))
+	assert.Equal(t, "0", readSysfsFile(t, filepath.Join(chip, "pwm1", "duty_cycle")))
+	assert.Equal(t, "1", readSysfsFile(t, filepath.Join(chip, "pwm1", "enable")))
+
+	assert.NoError(t, pwm.SetDutyCycle(40))
+	assert.Equal(t, "16000", readSysfsFile(t, filepath.Join(chip, "pwm1", "duty_cycle")))
+	assert.Equal(t, uint8(40), pwm.DutyCycle())
+
+	assert.NoError(t, pwm.SetDutyCycle(120))
+	assert.Equal(t, "40000", readSysfsFile(t, filepath.Join(chip, "pwm1", "duty_cycle")))
+
+	// A channel that doesn't appear after the export can't be used
+	_, err = hal.OpenSysfsPwm(chip, 2, 25000)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Equal(t, "2", readSysfsFile(t, filepath.Join(chip, "export")))
+
+	_, err = hal.OpenSysfsPwm(chip, 1, 0)
+	assert.EqualError(t, err, "PWM frequency must not be 0")
+}
+
+func TestSysfsFanUnit(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"pwmchip0/pwm0/period":          "0",
+		"pwmchip0/pwm0/duty_cycle":      "0",
+		"pwmchip0/pwm0/enable":          "0",
+		"cooling_fan/hwmon3/fan1_input": "1200",
+	})
+
+	pwm, err := hal.OpenSysfsPwm(filepath.Join(root, "pwmchip0"), 0, 25000)
+	assert.NoError(t, err)
+
+	fanUnit := hal.NewSysfsFanUnit(pwm, filepath.Join(root, "cooling_fan", "hwmon*", "fan1_input"))
+	assert.EqualValues(t, hal.FanUnitKindStandard, fanUnit.Kind())
+	assert.NoError(t, fanUnit.SetFanSpeedPercent(context.Background(), 75))
+	assert.Equal(t, "30000", readSysfsFile(t, filepath.Join(root, "pwmchip0", "pwm0", "duty_cycle")))
+
+	rpm, err := fanUnit.FanSpeedRPM(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1200.0, rpm)
+
+	// Without tachometer, the fan speed isn't reported
+	fanUnit = hal.NewSysfsFanUnit(pwm, "")
+	assert.EqualValues(t, hal.FanUnitKindStandardNoRPM, fanUnit.Kind())
+	rpm, err = fanUnit.FanSpeedRPM(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, rpm)
+}
+
+func TestSysfsLedBackend(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeSysfsFiles(t, root, map[string]string{
+		"rgb:edge/max_brightness":  "100\n",
+		"rgb:edge/multi_index":     "green red blue\n",
+		"rgb:edge/multi_intensity": "0 0 0\n",
+		"rgb:edge/brightness":      "0\n",
+	})
+
+	// The top LED isn't configured and discarded
+	backend, err := hal.NewLedBackend(hal.LedBackendOpts{
+		Backend: hal.LedBackendSysfs,
+		Edge:    filepath.Join(root, "rgb:edge"),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, backend.SetLed(hal.LedTop, led.Color{Red: 255}))
+
+	// Intensities are written in the order of multi_index, scaled to max_brightness
+	assert.NoError(t, backend.SetLed(hal.LedEdge, led.Color{Red: 255, Green: 51, Blue: 0}))
+	assert.Equal(t, "20 100 0", readSysfsFile(t, filepath.Join(root, "rgb:edge", "multi_intensity")))
+	assert.Equal(t, "100", readSysfsFile(t, filepath.Join(root, "rgb:edge", "brightness")))
+
+	assert.NoError(t, backend.SetLed(hal.LedEdge, led.Color{}))
+	assert.Equal(t, "0", readSysfsFile(t, filepath.Join(root, "rgb:edge", "brightness")))
+
+	assert.Error(t, backend.SetLed(hal.LedIndex(2), led.Color{}))
+}
+
+func TestNewLedBackend_Errors(t *testing.T) {
+	t.Parallel()
+
+	backend, err := hal.NewLedBackend(hal.LedBackendOpts{})
+	assert.NoError(t, err)
+	assert.NoError(t, backend.SetLed(hal.LedEdge, led.Color{Red: 255}))
+
+	_, err = hal.NewLedBackend(hal.LedBackendOpts{Backend: "ws2812"})
+	assert.EqualError(t, err, `unknown LED backend "ws2812"`)
+
+	_, err = hal.NewLedBackend(hal.LedBackendOpts{Backend: hal.LedBackendSysfs, Top: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to open the top LED")
+}
@@ -0,0 +1,139 @@
+package ledengine
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+)
+
+// Interpolation defines how the color changes towards the color of a keyframe
+type Interpolation uint8
+
+const (
+	// InterpolationStep shows the color of the keyframe right away and holds it for the keyframe duration
+	InterpolationStep Interpolation = iota
+	// InterpolationLinear fades linearly from the previous color to the color of the keyframe
+	InterpolationLinear
+	// InterpolationEase fades from the previous color to the color of the keyframe, starting and ending slowly
+	InterpolationEase
+)
+
+func (i Interpolation) String() string {
+	switch i {
+	case InterpolationStep:
+		return "step"
+	case InterpolationLinear:
+		return "linear"
+	case InterpolationEase:
+		return "ease"
+	default:
+		return "unknown"
+	}
+}
+
+// progress maps the elapsed fraction t (0..1) of a keyframe to the fraction of the color change
+func (i Interpolation) progress(t float64) float64 {
+	switch i {
+	case InterpolationLinear:
+		return t
+	case InterpolationEase:
+		return (1 - math.Cos(math.Pi*t)) / 2
+	default:
+		return 1
+	}
+}
+
+// Keyframe is a color reached after a duration
+type Keyframe struct {
+	// Color is the color of the keyframe
+	Color led.Color
+	// Duration is the time the color is held (step), or the time of the fade from the previous color (linear, ease)
+	Duration time.Duration
+	// Interpolation defines how the color changes from the previous keyframe
+	Interpolation Interpolation
+}
+
+// Animation is a sequence of keyframes, which is repeated until the animation is replaced.
+// The first keyframe fades from the color of the last keyframe.
+type Animation struct {
+	Keyframes []Keyframe
+}
+
+// Validate checks that the animation can be rendered
+func (a Animation) Validate() error {
+	if len(a.Keyframes) == 0 {
+		return errors.New("animation must have at least one keyframe")
+	}
+
+	var total time.Duration
+	for _, keyframe := range a.Keyframes {
+		if keyframe.Duration < 0 {
+			return errors.New("keyframe duration must not be negative")
+		}
+		if keyframe.Interpolation > InterpolationEase {
+			return errors.New("unknown keyframe interpolation")
+		}
+		total += keyframe.Duration
+	}
+	if total == 0 {
+		return errors.New("animation must have a duration")
+	}
+
+	return nil
+}
+
+// Blend returns the color at fraction t (0..1) of the way from one color to another
+func Blend(from, to led.Color, t float64) led.Color {
+	t = math.Max(0, math.Min(1, t))
+	blend := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return led.Color{
+		Red:   blend(from.Red, to.Red),
+		Green: blend(from.Green, to.Green),
+		Blue:  blend(from.Blue, to.Blue),
+	}
+}
+
+// NewStaticAnimation creates an animation showing a single color
+func NewStaticAnimation(color led.Color) Animation {
+	return NewStaticPattern(color).Animation()
+}
+
+// NewBreathingAnimation creates an animation smoothly fading between baseColor and activeColor, once per period
+func NewBreathingAnimation(baseColor led.Color, activeColor led.Color, period time.Duration) Animation {
+	return Animation{
+		Keyframes: []Keyframe{
+			{Color: baseColor, Duration: period / 2, Interpolation: InterpolationEase},
+			{Color: activeColor, Duration: period / 2, Interpolation: InterpolationEase},
+		},
+	}
+}
+
+// NewFadeAnimation creates an animation fading from fromColor to toColor over duration, holding toColor afterwards
+func NewFadeAnimation(fromColor led.Color, toColor led.Color, duration time.Duration) Animation {
+	return Animation{
+		Keyframes: []Keyframe{
+			{Color: fromColor},
+			{Color: toColor, Duration: duration, Interpolation: InterpolationLinear},
+			// Holding the final color for a long time, as the animation starts over afterwards
+			{Color: toColor, Duration: 24 * time.Hour},
+		},
+	}
+}
+
+// Animation returns the blink pattern as animation. Each delay becomes a step keyframe, alternating between the base
+// and the active color.
+func (p BlinkPattern) Animation() Animation {
+	keyframes := make([]Keyframe, 0, len(p.Delays))
+	for idx, delay := range p.Delays {
+		color := p.BaseColor
+		if idx%2 == 1 {
+			color = p.ActiveColor
+		}
+		keyframes = append(keyframes, Keyframe{Color: color, Duration: delay})
+	}
+	return Animation{Keyframes: keyframes}
+}
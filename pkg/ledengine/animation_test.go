@@ -0,0 +1,236 @@
+package ledengine_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBlinkPattern_Animation(t *testing.T) {
+	t.Parallel()
+
+	animation := ledengine.NewSlowBlinkPattern(led.Color{}, led.Color{Red: 255}).Animation()
+	assert.Equal(t, ledengine.Animation{
+		Keyframes: []ledengine.Keyframe{
+			{Color: led.Color{}, Duration: time.Second, Interpolation: ledengine.InterpolationStep},
+			{Color: led.Color{Red: 255}, Duration: time.Second, Interpolation: ledengine.InterpolationStep},
+		},
+	}, animation)
+
+	animation = ledengine.NewBurstPattern(led.Color{Green: 255}, led.Color{Red: 255}).Animation()
+	assert.Len(t, animation.Keyframes, 6)
+	assert.Equal(t, led.Color{Green: 255}, animation.Keyframes[0].Color)
+	assert.Equal(t, 500*time.Millisecond, animation.Keyframes[0].Duration)
+	assert.Equal(t, led.Color{Red: 255}, animation.Keyframes[5].Color)
+	assert.NoError(t, animation.Validate())
+}
+
+func TestBlend(t *testing.T) {
+	t.Parallel()
+
+	from := led.Color{Red: 200, Green: 0, Blue: 100}
+	to := led.Color{Red: 0, Green: 255, Blue: 100}
+	assert.Equal(t, from, ledengine.Blend(from, to, 0))
+	assert.Equal(t, led.Color{Red: 100, Green: 128, Blue: 100}, ledengine.Blend(from, to, 0.5))
+	assert.Equal(t, to, ledengine.Blend(from, to, 1))
+	assert.Equal(t, to, ledengine.Blend(from, to, 2))
+}
+
+func TestAnimation_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		animation ledengine.Animation
+		errMsg    string
+	}{
+		{
+			name:      "No keyframes",
+			animation: ledengine.Animation{},
+			errMsg:    "animation must have at least one keyframe",
+		},
+		{
+			name:      "Negative duration",
+			animation: ledengine.Animation{Keyframes: []ledengine.Keyframe{{Duration: -time.Second}}},
+			errMsg:    "keyframe duration must not be negative",
+		},
+		{
+			name:      "Unknown interpolation",
+			animation: ledengine.Animation{Keyframes: []ledengine.Keyframe{{Duration: time.Second, Interpolation: 42}}},
+			errMsg:    "unknown keyframe interpolation",
+		},
+		{
+			name:      "No duration",
+			animation: ledengine.Animation{Keyframes: []ledengine.Keyframe{{}, {Color: led.Color{Red: 255}}}},
+			errMsg:    "animation must have a duration",
+		},
+	}
+
+	for _, tc := range testCases {
+		animation := tc.animation
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.EqualError(t, animation.Validate(), expectedErrMsg)
+
+			engine := ledengine.NewLedEngine(ledengine.Options{Hal: &hal.ComputeBladeHalMock{}})
+			assert.EqualError(t, engine.SetAnimation(animation), expectedErrMsg)
+		})
+	}
+}
+
+func Test_LedEngine_SetAnimation_Fade(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		interpolation ledengine.Interpolation
+		frames        []uint8
+	}{
+		{"Linear", ledengine.InterpolationLinear, []uint8{50, 100, 150, 200}},
+		{"Ease", ledengine.InterpolationEase, []uint8{29, 100, 171, 200}},
+	}
+
+	for _, tc := range testCases {
+		interpolation := tc.interpolation
+		frames := tc.frames
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The start color is shown for one frame, then each frame of the fade is rendered right away
+			frameChan := make(chan time.Time, len(frames)+1)
+			for range len(frames) + 1 {
+				frameChan <- time.Time{}
+			}
+			clk := util.MockClock{}
+			clk.On("After", 250*time.Millisecond).Return(frameChan)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cbMock := hal.ComputeBladeHalMock{}
+			calls := []*mock.Call{cbMock.On("SetLed", hal.LedTop, led.Color{}).Once().Return(nil)}
+			for _, red := range frames {
+				calls = append(calls, cbMock.On("SetLed", hal.LedTop, led.Color{Red: red}).Once().Return(nil))
+			}
+			// The animation starts over
+			calls = append(calls, cbMock.On("SetLed", hal.LedTop, led.Color{}).Once().Return(nil).Run(func(mock.Arguments) {
+				cancel()
+			}))
+			mock.InOrder(calls...)
+
+			engine := ledengine.NewLedEngine(ledengine.Options{
+				Hal:       &cbMock,
+				Clock:     &clk,
+				LedIdx:    hal.LedTop,
+				FrameRate: 4,
+			})
+			err := engine.SetAnimation(ledengine.Animation{
+				Keyframes: []ledengine.Keyframe{
+					{Color: led.Color{}},
+					{Color: led.Color{Red: 200}, Duration: time.Second, Interpolation: interpolation},
+				},
+			})
+			assert.NoError(t, err)
+
+			assert.ErrorIs(t, engine.Run(ctx), context.Canceled)
+			cbMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_LedEngine_SetAnimation_BoundedFrameRate(t *testing.T) {
+	t.Parallel()
+
+	clk := util.MockClock{}
+	clkAfterChan := make(chan time.Time)
+	clk.On("After", 20*time.Millisecond).Once().Return(clkAfterChan)
+
+	cbMock := hal.ComputeBladeHalMock{}
+	engine := ledengine.NewLedEngine(ledengine.Options{
+		Hal:       &cbMock,
+		Clock:     &clk,
+		LedIdx:    hal.LedTop,
+		FrameRate: 1000,
+	})
+	err := engine.SetAnimation(ledengine.NewBreathingAnimation(led.Color{}, led.Color{Blue: 255}, 2*time.Second))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.ErrorIs(t, engine.Run(ctx), context.Canceled)
+	}()
+
+	// The first frame is awaited at 50 frames per second
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	clk.AssertExpectations(t)
+	cbMock.AssertExpectations(t)
+}
+
+func Test_LedEngine_SetAnimation_ShortSteps(t *testing.T) {
+	t.Parallel()
+
+	// Steps shorter than a frame are held for a full frame
+	frameChan := make(chan time.Time, 3)
+	for range 3 {
+		frameChan <- time.Time{}
+	}
+	clk := util.MockClock{}
+	clk.On("After", 20*time.Millisecond).Return(frameChan)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cbMock := hal.ComputeBladeHalMock{}
+	mock.InOrder(
+		cbMock.On("SetLed", hal.LedTop, led.Color{Red: 1}).Once().Return(nil),
+		cbMock.On("SetLed", hal.LedTop, led.Color{Red: 2}).Once().Return(nil),
+		cbMock.On("SetLed", hal.LedTop, led.Color{Red: 3}).Once().Return(nil),
+		// The animation starts over
+		cbMock.On("SetLed", hal.LedTop, led.Color{Red: 1}).Once().Return(nil).Run(func(mock.Arguments) {
+			cancel()
+		}),
+	)
+
+	engine := ledengine.NewLedEngine(ledengine.Options{
+		Hal:       &cbMock,
+		Clock:     &clk,
+		LedIdx:    hal.LedTop,
+		FrameRate: 50,
+	})
+	err := engine.SetAnimation(ledengine.Animation{
+		Keyframes: []ledengine.Keyframe{
+			{Color: led.Color{Red: 1}, Duration: time.Millisecond},
+			{Color: led.Color{Red: 2}},
+			{Color: led.Color{Red: 3}},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, engine.Run(ctx), context.Canceled)
+	clk.AssertExpectations(t)
+	cbMock.AssertExpectations(t)
+}
+
+func TestNewFadeAnimation(t *testing.T) {
+	t.Parallel()
+
+	animation := ledengine.NewFadeAnimation(led.Color{}, led.Color{Green: 255}, time.Second)
+	assert.NoError(t, animation.Validate())
+	assert.Equal(t, led.Color{}, animation.Keyframes[0].Color)
+	assert.Equal(t, led.Color{Green: 255}, animation.Keyframes[len(animation.Keyframes)-1].Color)
+}
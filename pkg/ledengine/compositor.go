@@ -0,0 +1,186 @@
+package ledengine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+)
+
+// Layer is a source of LED animations. Higher layers take precedence over lower layers.
+type Layer uint8
+
+const (
+	// LayerIdle is the animation shown when nothing else is going on
+	LayerIdle Layer = iota
+	// LayerUser is an animation requested by the user
+	LayerUser
+	// LayerFault shows the blink codes of active agent faults
+	LayerFault
+	// LayerIdentify is shown while the blade is being identified
+	LayerIdentify
+	// LayerFanFailure is shown while the fan has failed
+	LayerFanFailure
+	// LayerCritical is shown while the blade is in critical mode
+	LayerCritical
+)
+
+func (l Layer) String() string {
+	switch l {
+	case LayerIdle:
+		return "idle"
+	case LayerUser:
+		return "user"
+	case LayerFault:
+		return "fault"
+	case LayerIdentify:
+		return "identify"
+	case LayerFanFailure:
+		return "fan_failure"
+	case LayerCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Compositor shows the animation of the highest active layer on an LED engine.
+// Removing a layer reveals the layer below, the LED is turned off if no layer is active.
+type Compositor struct {
+	mu     sync.Mutex
+	engine LedEngine
+	clock  util.Clock
+	layers map[Layer]Animation
+	// generations counts the changes of each layer, so an expiring layer doesn't remove a later animation
+	generations map[Layer]uint64
+
+	// shown is the layer currently shown, nil if the LED is off
+	shown *Layer
+}
+
+// NewCompositor creates a new Compositor driving the given LED engine. If clock is nil, the real clock is used.
+func NewCompositor(engine LedEngine, clock util.Clock) *Compositor {
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &Compositor{
+		engine:      engine,
+		clock:       clock,
+		layers:      make(map[Layer]Animation),
+		generations: make(map[Layer]uint64),
+	}
+}
+
+// Set activates the layer with the given animation, replacing any previous animation of the layer
+func (c *Compositor) Set(layer Layer, animation Animation) error {
+	return c.SetFor(layer, animation, 0)
+}
+
+// SetFor activates the layer with the given animation for the given time to live, after which the layer is removed.
+// A ttl of 0 keeps the layer until it is cleared.
+func (c *Compositor) SetFor(layer Layer, animation Animation, ttl time.Duration) error {
+	if err := animation.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.layers[layer] = animation
+	c.generations[layer]++
+	if ttl > 0 {
+		go c.expire(layer, c.generations[layer], c.clock.After(ttl))
+	}
+
+	// The animation of the shown layer changed, restart it
+	if c.shown != nil && *c.shown == layer {
+		return c.engine.SetAnimation(animation)
+	}
+	return c.update()
+}
+
+// SetPattern activates the layer with the given blink pattern
+func (c *Compositor) SetPattern(layer Layer, pattern BlinkPattern) error {
+	return c.Set(layer, pattern.Animation())
+}
+
+// Clear deactivates the layer, revealing the highest layer below
+func (c *Compositor) Clear(layer Layer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.clear(layer)
+}
+
+// clear removes the layer. The caller must hold the lock.
+func (c *Compositor) clear(layer Layer) error {
+	if _, ok := c.layers[layer]; !ok {
+		return nil
+	}
+	delete(c.layers, layer)
+	c.generations[layer]++
+
+	return c.update()
+}
+
+// expire removes the layer once expired fires, unless it has been changed in the meantime
+func (c *Compositor) expire(layer Layer, generation uint64, expired <-chan time.Time) {
+	<-expired
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[layer] != generation {
+		return
+	}
+	// Failures to update the LED can't be reported, the next change of any layer retries
+	_ = c.clear(layer)
+}
+
+// Active returns the layer currently shown, false if no layer is active
+func (c *Compositor) Active() (Layer, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shown == nil {
+		return 0, false
+	}
+	return *c.shown, true
+}
+
+// Run runs the underlying LED engine
+func (c *Compositor) Run(ctx context.Context) error {
+	return c.engine.Run(ctx)
+}
+
+// update shows the highest active layer, if it is not shown already. The caller must hold the lock.
+func (c *Compositor) update() error {
+	var top *Layer
+	for layer := range c.layers {
+		if top == nil || layer > *top {
+			l := layer
+			top = &l
+		}
+	}
+
+	switch {
+	case top == nil && c.shown == nil:
+		return nil
+	case top != nil && c.shown != nil && *top == *c.shown:
+		return nil
+	}
+
+	animation := NewStaticAnimation(led.Color{})
+	if top != nil {
+		animation = c.layers[*top]
+	}
+	if err := c.engine.SetAnimation(animation); err != nil {
+		return err
+	}
+
+	c.shown = top
+	return nil
+}
@@ -0,0 +1,158 @@
+package ledengine_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingEngine is a LedEngine recording the animations set
+type recordingEngine struct {
+	mu         sync.Mutex
+	animations []ledengine.Animation
+}
+
+func (e *recordingEngine) SetPattern(pattern ledengine.BlinkPattern) error {
+	return e.SetAnimation(pattern.Animation())
+}
+
+func (e *recordingEngine) SetAnimation(animation ledengine.Animation) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.animations = append(e.animations, animation)
+	return nil
+}
+
+func (e *recordingEngine) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// last returns the animation set most recently
+func (e *recordingEngine) last() ledengine.Animation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.animations[len(e.animations)-1]
+}
+
+func TestCompositor(t *testing.T) {
+	t.Parallel()
+
+	idle := ledengine.NewStaticAnimation(led.Color{Green: 32})
+	identify := ledengine.NewBurstPattern(led.Color{}, led.Color{Blue: 255}).Animation()
+	critical := ledengine.NewSlowBlinkPattern(led.Color{}, led.Color{Red: 255}).Animation()
+	off := ledengine.NewStaticAnimation(led.Color{})
+
+	engine := &recordingEngine{}
+	compositor := ledengine.NewCompositor(engine, nil)
+	_, ok := compositor.Active()
+	assert.False(t, ok)
+
+	assert.NoError(t, compositor.Set(ledengine.LayerIdle, idle))
+	assert.Equal(t, idle, engine.last())
+
+	assert.NoError(t, compositor.Set(ledengine.LayerCritical, critical))
+	assert.Equal(t, critical, engine.last())
+
+	// Lower layers don't interrupt the shown animation
+	assert.NoError(t, compositor.Set(ledengine.LayerIdentify, identify))
+	assert.Len(t, engine.animations, 2)
+	layer, ok := compositor.Active()
+	assert.True(t, ok)
+	assert.Equal(t, ledengine.LayerCritical, layer)
+
+	// Removing a layer reveals the highest layer below
+	assert.NoError(t, compositor.Clear(ledengine.LayerCritical))
+	assert.Equal(t, identify, engine.last())
+	assert.NoError(t, compositor.Clear(ledengine.LayerIdentify))
+	assert.Equal(t, idle, engine.last())
+
+	// Clearing inactive layers is a no-op
+	assert.NoError(t, compositor.Clear(ledengine.LayerIdentify))
+	assert.Len(t, engine.animations, 4)
+
+	// Updating the shown layer restarts its animation
+	idle = ledengine.NewStaticAnimation(led.Color{Green: 64})
+	assert.NoError(t, compositor.Set(ledengine.LayerIdle, idle))
+	assert.Equal(t, idle, engine.last())
+
+	// Without any layer, the LED is turned off
+	assert.NoError(t, compositor.Clear(ledengine.LayerIdle))
+	assert.Equal(t, off, engine.last())
+	_, ok = compositor.Active()
+	assert.False(t, ok)
+}
+
+func TestCompositor_SetFor(t *testing.T) {
+	t.Parallel()
+
+	firstExpiry := make(chan time.Time, 1)
+	secondExpiry := make(chan time.Time, 1)
+	clk := &util.MockClock{}
+	clk.On("After", time.Minute).Once().Return(firstExpiry)
+	clk.On("After", time.Minute).Once().Return(secondExpiry)
+
+	idle := ledengine.NewStaticAnimation(led.Color{Green: 32})
+	user := ledengine.NewStaticAnimation(led.Color{Blue: 255})
+	engine := &recordingEngine{}
+	compositor := ledengine.NewCompositor(engine, clk)
+	assert.NoError(t, compositor.Set(ledengine.LayerIdle, idle))
+
+	assert.NoError(t, compositor.SetFor(ledengine.LayerUser, user, time.Minute))
+	assert.Equal(t, user, engine.last())
+
+	// Replacing the layer keeps it beyond the first time to live
+	assert.NoError(t, compositor.SetFor(ledengine.LayerUser, user, time.Minute))
+	firstExpiry <- time.Time{}
+	time.Sleep(5 * time.Millisecond)
+	layer, _ := compositor.Active()
+	assert.Equal(t, ledengine.LayerUser, layer)
+
+	// Once expired, the layer below is revealed
+	secondExpiry <- time.Time{}
+	assert.Eventually(t, func() bool {
+		layer, _ := compositor.Active()
+		return layer == ledengine.LayerIdle
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, idle, engine.last())
+	clk.AssertExpectations(t)
+}
+
+func TestCompositor_InvalidAnimation(t *testing.T) {
+	t.Parallel()
+
+	engine := &recordingEngine{}
+	compositor := ledengine.NewCompositor(engine, nil)
+
+	err := compositor.Set(ledengine.LayerUser, ledengine.Animation{})
+	assert.EqualError(t, err, "animation must have at least one keyframe")
+	err = compositor.SetPattern(ledengine.LayerUser, ledengine.BlinkPattern{})
+	assert.EqualError(t, err, "animation must have at least one keyframe")
+	assert.Empty(t, engine.animations)
+	_, ok := compositor.Active()
+	assert.False(t, ok)
+}
+
+func TestCompositor_Run(t *testing.T) {
+	t.Parallel()
+
+	compositor := ledengine.NewCompositor(&recordingEngine{}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, compositor.Run(ctx), context.DeadlineExceeded)
+}
+
+func TestLayer_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "idle", ledengine.LayerIdle.String())
+	assert.Equal(t, "fault", ledengine.LayerFault.String())
+	assert.Equal(t, "fan_failure", ledengine.LayerFanFailure.String())
+	assert.Equal(t, "critical", ledengine.LayerCritical.String())
+}
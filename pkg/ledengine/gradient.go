@@ -0,0 +1,27 @@
+package ledengine
+
+import (
+	"math"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+)
+
+// Gradient is a sequence of colors, evenly spaced between the positions 0 and 1
+type Gradient []led.Color
+
+// At returns the color at position t, blending the two closest colors. Positions outside of 0..1 are clamped.
+// An empty gradient is off.
+func (g Gradient) At(t float64) led.Color {
+	switch len(g) {
+	case 0:
+		return led.Color{}
+	case 1:
+		return g[0]
+	}
+
+	t = math.Max(0, math.Min(1, t))
+	segment := t * float64(len(g)-1)
+	idx := min(int(segment), len(g)-2)
+
+	return Blend(g[idx], g[idx+1], segment-float64(idx))
+}
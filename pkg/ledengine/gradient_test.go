@@ -0,0 +1,39 @@
+package ledengine_test
+
+import (
+	"testing"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/hal/led"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/ledengine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGradient_At(t *testing.T) {
+	t.Parallel()
+
+	gradient := ledengine.Gradient{{Green: 16}, {Red: 16, Green: 16}, {Red: 16}}
+
+	testCases := []struct {
+		t        float64
+		expected led.Color
+	}{
+		{-1, led.Color{Green: 16}}, // Clamped to the first color
+		{0, led.Color{Green: 16}},
+		{0.25, led.Color{Red: 8, Green: 16}},
+		{0.5, led.Color{Red: 16, Green: 16}},
+		{0.75, led.Color{Red: 16, Green: 8}},
+		{1, led.Color{Red: 16}},
+		{2, led.Color{Red: 16}}, // Clamped to the last color
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, gradient.At(tc.t), "at %.2f", tc.t)
+	}
+}
+
+func TestGradient_AtShort(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, led.Color{}, ledengine.Gradient{}.At(0.5))
+	assert.Equal(t, led.Color{Blue: 32}, ledengine.Gradient{{Blue: 32}}.At(0.5))
+}
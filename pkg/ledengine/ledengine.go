@@ -3,6 +3,7 @@ package ledengine
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/compute-blade-community/compute-blade-agent/pkg/hal"
@@ -14,17 +15,31 @@ import (
 type LedEngine interface {
 	// SetPattern sets the blink pattern
 	SetPattern(pattern BlinkPattern) error
+	// SetAnimation sets the animation
+	SetAnimation(animation Animation) error
 	// Run runs the LED Engine
 	Run(ctx context.Context) error
 }
 
+const (
+	// defaultFrameRate is the number of color updates per second while fading
+	defaultFrameRate = 25
+	// maxFrameRate bounds the number of color updates per second while fading
+	maxFrameRate = 50
+)
+
+// errRestart is returned while rendering when the animation has been replaced
+var errRestart = errors.New("animation restarted")
+
 // ledEngineImpl is the implementation of the LedEngine interface
 type ledEngineImpl struct {
-	ledIdx  hal.LedIndex
-	restart chan struct{}
-	pattern BlinkPattern
-	hal     hal.ComputeBladeHal
-	clock   util.Clock
+	ledIdx        hal.LedIndex
+	mu            sync.Mutex
+	restart       chan struct{}
+	animation     Animation
+	hal           hal.ComputeBladeHal
+	clock         util.Clock
+	frameInterval time.Duration
 }
 
 type BlinkPattern struct {
@@ -101,6 +116,34 @@ func NewSlowBlinkPattern(baseColor led.Color, activeColor led.Color) BlinkPatter
 	}
 }
 
+const (
+	// blinkCodePause is the time the LED is off before a blink code starts
+	blinkCodePause = 2 * time.Second
+	// blinkCodeFlash and blinkCodeGap are the on and off times of each flash of a blink code
+	blinkCodeFlash = 200 * time.Millisecond
+	blinkCodeGap   = 300 * time.Millisecond
+)
+
+// NewBlinkCodePattern creates a pattern flashing each code as that many short flashes followed by a pause,
+// e.g. (pause) -> 3x flash -> (pause) -> 1x flash for codes 3 and 1. Codes of 0 are skipped.
+func NewBlinkCodePattern(baseColor led.Color, activeColor led.Color, codes ...uint8) BlinkPattern {
+	pattern := BlinkPattern{
+		BaseColor:   baseColor,
+		ActiveColor: activeColor,
+	}
+	for _, code := range codes {
+		for flash := range code {
+			if flash == 0 {
+				pattern.Delays = append(pattern.Delays, blinkCodePause)
+			} else {
+				pattern.Delays = append(pattern.Delays, blinkCodeGap)
+			}
+			pattern.Delays = append(pattern.Delays, blinkCodeFlash)
+		}
+	}
+	return pattern
+}
+
 func New(hal hal.ComputeBladeHal, ledIdx hal.LedIndex) LedEngine {
 	return NewLedEngine(Options{
 		Hal:    hal,
@@ -113,12 +156,20 @@ func NewLedEngine(opts Options) LedEngine {
 	if clock == nil {
 		clock = util.RealClock{}
 	}
+
+	frameRate := opts.FrameRate
+	if frameRate <= 0 {
+		frameRate = defaultFrameRate
+	}
+	frameRate = min(frameRate, maxFrameRate)
+
 	return &ledEngineImpl{
-		ledIdx:  opts.LedIdx,
-		hal:     opts.Hal,
-		restart: make(chan struct{}),           // restart channel controls cancellation of any pattern
-		pattern: NewStaticPattern(led.Color{}), // Turn off LEDs by default
-		clock:   clock,
+		ledIdx:        opts.LedIdx,
+		hal:           opts.Hal,
+		restart:       make(chan struct{}),             // restart channel controls cancellation of any animation
+		animation:     NewStaticAnimation(led.Color{}), // Turn off LEDs by default
+		clock:         clock,
+		frameInterval: time.Second / time.Duration(frameRate),
 	}
 }
 
@@ -127,41 +178,92 @@ func (b *ledEngineImpl) SetPattern(pattern BlinkPattern) error {
 		return errors.New("pattern must have at least one delay")
 	}
 
-	b.pattern = pattern
+	return b.SetAnimation(pattern.Animation())
+}
+
+func (b *ledEngineImpl) SetAnimation(animation Animation) error {
+	if err := animation.Validate(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.animation = animation
 	close(b.restart)
 	b.restart = make(chan struct{})
 
 	return nil
 }
 
-// Run runs the blink engine
+// Run runs the LED engine, rendering the current animation until the context is done
 func (b *ledEngineImpl) Run(ctx context.Context) error {
 	// Iterate forever unless context is done
 	for {
-		// Set the base color
-		if err := b.hal.SetLed(b.ledIdx, b.pattern.BaseColor); err != nil {
+		b.mu.Lock()
+		animation, restart := b.animation, b.restart
+		b.mu.Unlock()
+
+		// Repeat the animation, and start over whenever it is replaced
+		if err := b.render(ctx, animation, restart); err != nil && !errors.Is(err, errRestart) {
 			return err
 		}
-		//  Iterate through pattern delays
-	PatternLoop:
-		for idx, delay := range b.pattern.Delays {
-			select {
-			// Whenever the pattern is restarted, break the loop and start over
-			case <-b.restart:
-				break PatternLoop
-			// Whenever the context is done, return
-			case <-ctx.Done():
-				return ctx.Err()
-			// Whenever the delay is over, change the color
-			case <-b.clock.After(delay):
-				color := b.pattern.BaseColor
-				if idx%2 == 0 {
-					color = b.pattern.ActiveColor
-				}
-				if err := b.hal.SetLed(b.ledIdx, color); err != nil {
-					return err
-				}
+	}
+}
+
+// render renders the animation once. Fades update the color once per frame, steps last at least one frame.
+func (b *ledEngineImpl) render(ctx context.Context, animation Animation, restart <-chan struct{}) error {
+	previous := animation.Keyframes[len(animation.Keyframes)-1].Color
+	for _, keyframe := range animation.Keyframes {
+		if keyframe.Interpolation == InterpolationStep {
+			if err := b.hal.SetLed(b.ledIdx, keyframe.Color); err != nil {
+				return err
 			}
+			// Each step is shown for at least one frame, so short steps can't drive SetLed beyond the frame rate
+			if err := b.wait(ctx, restart, max(keyframe.Duration, b.frameInterval)); err != nil {
+				return err
+			}
+			previous = keyframe.Color
+			continue
 		}
+
+		shown := previous
+		for elapsed := time.Duration(0); elapsed < keyframe.Duration; {
+			frame := min(b.frameInterval, keyframe.Duration-elapsed)
+			if err := b.wait(ctx, restart, frame); err != nil {
+				return err
+			}
+			elapsed += frame
+
+			t := keyframe.Interpolation.progress(float64(elapsed) / float64(keyframe.Duration))
+			color := Blend(previous, keyframe.Color, t)
+			// Skip frames without visible change
+			if color == shown {
+				continue
+			}
+			if err := b.hal.SetLed(b.ledIdx, color); err != nil {
+				return err
+			}
+			shown = color
+		}
+		previous = keyframe.Color
+	}
+
+	return nil
+}
+
+// wait blocks for the duration, unless the animation is replaced or the context is done
+func (b *ledEngineImpl) wait(ctx context.Context, restart <-chan struct{}, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	select {
+	case <-restart:
+		return errRestart
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.clock.After(d):
+		return nil
 	}
 }
@@ -113,6 +113,25 @@ func TestNewBurstPattern(t *testing.T) {
 	}
 }
 
+func TestNewBlinkCodePattern(t *testing.T) {
+	t.Parallel()
+
+	pattern := ledengine.NewBlinkCodePattern(led.Color{}, led.Color{Red: 255}, 2, 0, 1)
+	assert.Equal(t, led.Color{}, pattern.BaseColor)
+	assert.Equal(t, led.Color{Red: 255}, pattern.ActiveColor)
+	assert.Equal(t, []time.Duration{
+		2 * time.Second,        // pause
+		200 * time.Millisecond, // flash
+		300 * time.Millisecond, // gap
+		200 * time.Millisecond, // flash
+		2 * time.Second,        // pause
+		200 * time.Millisecond, // flash
+	}, pattern.Delays)
+
+	// Without codes, the pattern is rejected by the LED engine
+	assert.Empty(t, ledengine.NewBlinkCodePattern(led.Color{}, led.Color{Red: 255}).Delays)
+}
+
 func TestNewLedEngine(t *testing.T) {
 	t.Parallel()
 	engine := ledengine.Options{
@@ -13,4 +13,6 @@ type Options struct {
 	Hal hal.ComputeBladeHal
 	// Clock is the clock used for timing
 	Clock util.Clock
+	// FrameRate is the number of color updates per second while fading. Defaults to 25, at most 50.
+	FrameRate int
 }
@@ -0,0 +1,212 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/sierrasoftworks/humane-errors-go"
+)
+
+// Entry configures a schedule boundary at which the fan profile, stealth mode and/or LED brightness are switched
+type Entry struct {
+	// At is the time of day in 24h format (HH:MM)
+	At string `mapstructure:"at"`
+	// Days limits the entry to the given weekdays (mon, tue, ...). Empty means every day.
+	Days []string `mapstructure:"days"`
+	// FanProfile is the fan profile activated at the boundary, empty to keep the current fan profile
+	FanProfile string `mapstructure:"fan_profile"`
+	// StealthMode enables or disables stealth mode at the boundary, unset to keep the current stealth mode
+	StealthMode *bool `mapstructure:"stealth_mode"`
+	// LedBrightness is the LED brightness in percent applied at the boundary, unset to keep the current brightness
+	LedBrightness *uint8 `mapstructure:"led_brightness"`
+}
+
+// Transition is a change of the fan profile, stealth mode and/or LED brightness at a point in time
+type Transition struct {
+	// Time is the time of the boundary
+	Time time.Time
+	// FanProfile is the fan profile to activate, empty if unchanged
+	FanProfile string
+	// StealthMode is the stealth mode to apply, nil if unchanged
+	StealthMode *bool
+	// LedBrightness is the LED brightness in percent to apply, nil if unchanged
+	LedBrightness *uint8
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// entry is a parsed Entry
+type entry struct {
+	Entry
+	hour, minute int
+	days         [7]bool
+}
+
+// Schedule evaluates schedule entries against the clock.
+// Changes are only reported when a boundary is passed, so manual changes hold until the next boundary.
+type Schedule struct {
+	mu      sync.Mutex
+	entries []entry
+	clock   util.Clock
+	// last is the time of the previous evaluation, zero before the first one
+	last time.Time
+}
+
+// New validates the entries and creates a new Schedule. If clock is nil, the real clock is used.
+// Boundaries are evaluated in the location of the times returned by the clock.
+func New(entries []Entry, clock util.Clock) (*Schedule, humane.Error) {
+	parsed := make([]entry, 0, len(entries))
+	for _, e := range entries {
+		at, err := time.Parse("15:04", e.At)
+		if err != nil {
+			return nil, humane.Wrap(err, fmt.Sprintf("invalid schedule time %q", e.At),
+				"Use the 24h format HH:MM, e.g. 22:00",
+			)
+		}
+
+		p := entry{Entry: e, hour: at.Hour(), minute: at.Minute()}
+		for _, day := range e.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, humane.New(fmt.Sprintf("unknown schedule day %q", day),
+					"Use the weekday names mon, tue, wed, thu, fri, sat or sun",
+				)
+			}
+			p.days[weekday] = true
+		}
+		if len(e.Days) == 0 {
+			p.days = [7]bool{true, true, true, true, true, true, true}
+		}
+
+		if e.FanProfile == "" && e.StealthMode == nil && e.LedBrightness == nil {
+			return nil, humane.New(fmt.Sprintf("schedule entry at %s does not change anything", e.At),
+				"Set fan_profile, stealth_mode and/or led_brightness for every schedule entry",
+			)
+		}
+		if e.LedBrightness != nil && *e.LedBrightness > 100 {
+			return nil, humane.New(fmt.Sprintf("schedule entry at %s has LED brightness %d%% out of range", e.At, *e.LedBrightness),
+				"Use an LED brightness between 0 and 100",
+			)
+		}
+
+		parsed = append(parsed, p)
+	}
+
+	if clock == nil {
+		clock = util.RealClock{}
+	}
+
+	return &Schedule{
+		entries: parsed,
+		clock:   clock,
+	}, nil
+}
+
+// Due returns the combined transition of all boundaries passed since the previous call.
+// The first call returns the transition reflecting the current schedule state, so the schedule is in effect right after startup.
+func (s *Schedule) Due() (Transition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	from := s.last
+	if from.IsZero() {
+		// Every entry occurs at least once a week
+		from = now.AddDate(0, 0, -7)
+	}
+	if now.Before(from) {
+		// The clock went backwards, wait for the next boundary
+		s.last = now
+		return Transition{}, false
+	}
+	s.last = now
+
+	return merge(s.occurrences(from, now))
+}
+
+// Next returns the next upcoming transition
+func (s *Schedule) Next() (Transition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	occurrences := s.occurrences(now, now.AddDate(0, 0, 8))
+	if len(occurrences) == 0 {
+		return Transition{}, false
+	}
+
+	// Only combine the entries at the very next boundary
+	next := occurrences[0].Time
+	end := 1
+	for end < len(occurrences) && occurrences[end].Time.Equal(next) {
+		end++
+	}
+
+	return merge(occurrences[:end])
+}
+
+// occurrences returns all boundaries within (from, to], sorted by time
+func (s *Schedule) occurrences(from, to time.Time) []Transition {
+	var transitions []Transition
+
+	loc := to.Location()
+	from = from.In(loc)
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, e := range s.entries {
+			if !e.days[day.Weekday()] {
+				continue
+			}
+
+			at := time.Date(day.Year(), day.Month(), day.Day(), e.hour, e.minute, 0, 0, loc)
+			if at.After(from) && !at.After(to) {
+				transitions = append(transitions, Transition{
+					Time:          at,
+					FanProfile:    e.FanProfile,
+					StealthMode:   e.StealthMode,
+					LedBrightness: e.LedBrightness,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].Time.Before(transitions[j].Time)
+	})
+
+	return transitions
+}
+
+// merge combines transitions in order, later transitions take precedence
+func merge(transitions []Transition) (Transition, bool) {
+	if len(transitions) == 0 {
+		return Transition{}, false
+	}
+
+	var merged Transition
+	for _, t := range transitions {
+		merged.Time = t.Time
+		if t.FanProfile != "" {
+			merged.FanProfile = t.FanProfile
+		}
+		if t.StealthMode != nil {
+			merged.StealthMode = t.StealthMode
+		}
+		if t.LedBrightness != nil {
+			merged.LedBrightness = t.LedBrightness
+		}
+	}
+
+	return merged, true
+}
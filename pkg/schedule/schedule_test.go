@@ -0,0 +1,208 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/compute-blade-community/compute-blade-agent/pkg/schedule"
+	"github.com/compute-blade-community/compute-blade-agent/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	enabled  = true
+	disabled = false
+
+	// quietHours switches to the quiet profile with stealth mode in the evening and back in the morning on weekdays
+	quietHours = []schedule.Entry{
+		{At: "22:00", FanProfile: "quiet", StealthMode: &enabled},
+		{At: "07:30", Days: []string{"mon", "tue", "wed", "thu", "fri"}, FanProfile: "balanced", StealthMode: &disabled},
+		{At: "09:00", Days: []string{"Saturday", "Sunday"}, StealthMode: &disabled},
+	}
+)
+
+// date returns the given time on a day in June 2025, June 2nd being a Monday
+func date(day, hour, minute int) time.Time {
+	return time.Date(2025, time.June, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestSchedule_Due(t *testing.T) {
+	t.Parallel()
+
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(date(3, 23, 0))
+	clk.On("Now").Once().Return(date(3, 23, 30))
+	clk.On("Now").Once().Return(date(4, 7, 29))
+	clk.On("Now").Once().Return(date(4, 7, 30))
+	clk.On("Now").Once().Return(date(4, 12, 0))
+
+	sched, err := schedule.New(quietHours, clk)
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	// The first evaluation applies the current state
+	transition, ok := sched.Due()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(3, 22, 0), FanProfile: "quiet", StealthMode: &enabled}, transition)
+
+	// No boundary passed, manual changes are kept
+	_, ok = sched.Due()
+	assert.False(t, ok)
+	_, ok = sched.Due()
+	assert.False(t, ok)
+
+	// Next boundary
+	transition, ok = sched.Due()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(4, 7, 30), FanProfile: "balanced", StealthMode: &disabled}, transition)
+
+	_, ok = sched.Due()
+	assert.False(t, ok)
+	clk.AssertExpectations(t)
+}
+
+func TestSchedule_DueMergesPassedBoundaries(t *testing.T) {
+	t.Parallel()
+
+	clk := &util.MockClock{}
+	// Saturday morning, the quiet profile from Friday evening is kept while stealth mode was disabled at 09:00
+	clk.On("Now").Once().Return(date(7, 10, 0))
+	// The agent was suspended over the weekend
+	clk.On("Now").Once().Return(date(9, 8, 0))
+
+	sched, err := schedule.New(quietHours, clk)
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	transition, ok := sched.Due()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(7, 9, 0), FanProfile: "quiet", StealthMode: &disabled}, transition)
+
+	transition, ok = sched.Due()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(9, 7, 30), FanProfile: "balanced", StealthMode: &disabled}, transition)
+	clk.AssertExpectations(t)
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(date(6, 12, 0))
+	clk.On("Now").Once().Return(date(6, 22, 0))
+	clk.On("Now").Once().Return(date(6, 23, 0))
+
+	sched, err := schedule.New(quietHours, clk)
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	transition, ok := sched.Next()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(6, 22, 0), FanProfile: "quiet", StealthMode: &enabled}, transition)
+
+	// Boundaries at the current time have passed already
+	transition, ok = sched.Next()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(7, 9, 0), StealthMode: &disabled}, transition)
+
+	transition, ok = sched.Next()
+	assert.True(t, ok)
+	assert.Equal(t, date(7, 9, 0), transition.Time)
+	clk.AssertExpectations(t)
+}
+
+func TestSchedule_Empty(t *testing.T) {
+	t.Parallel()
+
+	clk := &util.MockClock{}
+	clk.On("Now").Return(date(2, 12, 0))
+
+	sched, err := schedule.New(nil, clk)
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	_, ok := sched.Due()
+	assert.False(t, ok)
+	_, ok = sched.Next()
+	assert.False(t, ok)
+}
+
+func TestSchedule_LedBrightness(t *testing.T) {
+	t.Parallel()
+
+	night, day := uint8(10), uint8(100)
+	clk := &util.MockClock{}
+	clk.On("Now").Once().Return(date(3, 23, 0))
+	clk.On("Now").Once().Return(date(4, 8, 0))
+	clk.On("Now").Once().Return(date(4, 8, 0))
+
+	sched, err := schedule.New([]schedule.Entry{
+		{At: "22:00", LedBrightness: &night},
+		{At: "07:00", LedBrightness: &day},
+		// Entries without brightness keep the brightness of earlier entries
+		{At: "07:30", StealthMode: &disabled},
+	}, clk)
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	transition, ok := sched.Due()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(3, 22, 0), StealthMode: &disabled, LedBrightness: &night}, transition)
+
+	transition, ok = sched.Due()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(4, 7, 30), StealthMode: &disabled, LedBrightness: &day}, transition)
+
+	transition, ok = sched.Next()
+	assert.True(t, ok)
+	assert.Equal(t, schedule.Transition{Time: date(4, 22, 0), LedBrightness: &night}, transition)
+	clk.AssertExpectations(t)
+}
+
+func TestSchedule_ConstructionErrors(t *testing.T) {
+	t.Parallel()
+
+	tooBright := uint8(120)
+
+	testCases := []struct {
+		name    string
+		entries []schedule.Entry
+		errMsg  string
+	}{
+		{
+			name:    "Invalid time",
+			entries: []schedule.Entry{{At: "25:00", StealthMode: &enabled}},
+			errMsg:  `invalid schedule time "25:00"`,
+		},
+		{
+			name:    "Unknown day",
+			entries: []schedule.Entry{{At: "22:00", Days: []string{"someday"}, StealthMode: &enabled}},
+			errMsg:  `unknown schedule day "someday"`,
+		},
+		{
+			name:    "No change",
+			entries: []schedule.Entry{{At: "22:00"}},
+			errMsg:  "schedule entry at 22:00 does not change anything",
+		},
+		{
+			name:    "LED brightness out of range",
+			entries: []schedule.Entry{{At: "22:00", LedBrightness: &tooBright}},
+			errMsg:  "schedule entry at 22:00 has LED brightness 120% out of range",
+		},
+	}
+
+	for _, tc := range testCases {
+		entries := tc.entries
+		expectedErrMsg := tc.errMsg
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := schedule.New(entries, nil)
+			assert.EqualError(t, err, expectedErrMsg)
+		})
+	}
+}